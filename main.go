@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -49,29 +50,35 @@ func run() int {
 				sentry.CurrentHub().Recover(err)
 				flushSentry()
 				logrus.Fatalf("Captured panic: %s", err)
-				os.Exit(2)
+				os.Exit(cmd.ExitCodeError)
 			}
 			flushSentry()
 		}
 	}()
 
 	if _, err := driftctlCmd.ExecuteC(); err != nil {
+		var exitErr cmd.ExitError
+		if errors.As(err, &exitErr) {
+			fmt.Fprintln(os.Stderr, color.YellowString("%s", err))
+			return exitErr.Code
+		}
 		if cmd.IsReportingEnabled(&driftctlCmd.Command) {
 			sentry.CaptureException(err)
 		}
 		fmt.Fprintln(os.Stderr, color.RedString("%s", err))
-		return 1
+		return cmd.ExitCodeError
 	}
 
 	if checkVersion {
 		newVersion := <-latestVersionChan
+		// Printed to stderr so it does not end up in output piped to a file (e.g. .driftignore or JSON)
 		if newVersion != "" {
-			fmt.Println("\n\nYour version of driftctl is outdated, please upgrade !")
-			fmt.Printf("Current: %s; Latest: %s\n", version.Current(), newVersion)
+			fmt.Fprintln(os.Stderr, "\n\nYour version of driftctl is outdated, please upgrade !")
+			fmt.Fprintf(os.Stderr, "Current: %s; Latest: %s\n", version.Current(), newVersion)
 		}
 	}
 
-	return 0
+	return cmd.ExitCodeInSync
 }
 
 func flushSentry() {
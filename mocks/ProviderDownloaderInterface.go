@@ -9,13 +9,13 @@ type ProviderDownloaderInterface struct {
 	mock.Mock
 }
 
-// Download provides a mock function with given fields: url, path
-func (_m *ProviderDownloaderInterface) Download(url string, path string) error {
-	ret := _m.Called(url, path)
+// Download provides a mock function with given fields: url, path, checksum
+func (_m *ProviderDownloaderInterface) Download(url string, path string, checksum string) error {
+	ret := _m.Called(url, path, checksum)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(url, path)
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(url, path, checksum)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -23,6 +23,27 @@ func (_m *ProviderDownloaderInterface) Download(url string, path string) error {
 	return r0
 }
 
+// GetProviderChecksum provides a mock function with given fields: name, version
+func (_m *ProviderDownloaderInterface) GetProviderChecksum(name string, version string) (string, error) {
+	ret := _m.Called(name, version)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(name, version)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(name, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProviderUrl provides a mock function with given fields: name, version
 func (_m *ProviderDownloaderInterface) GetProviderUrl(name string, version string) string {
 	ret := _m.Called(name, version)
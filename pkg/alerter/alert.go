@@ -1,8 +1,36 @@
 package alerter
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 type Alerts map[string][]Alert
 
 type Alert struct {
-	Message              string `json:"message"`
-	ShouldIgnoreResource bool   `json:"-"`
+	Message              string       `json:"message"`
+	ShouldIgnoreResource bool         `json:"-"`
+	ScanFailure          *ScanFailure `json:"scan_failure,omitempty"` // Set when resources could not be listed on the cloud provider
+}
+
+// ScanFailure describes resources of a type that could not be listed, the analysis is incomplete for this type
+type ScanFailure struct {
+	ResourceType string `json:"resource_type"`
+	Region       string `json:"region,omitempty"`
+	Account      string `json:"account,omitempty"`
+	Error        string `json:"error"`
+}
+
+// Covers returns whether a resource may be one of those that could not be listed, resources of other regions or
+// accounts are still analyzed while those whose region or account is unknown are assumed to be covered
+func (f *ScanFailure) Covers(res resource.Resource) bool {
+	if res.TerraformType() != f.ResourceType {
+		return false
+	}
+	meta := resource.GetMetadata(res)
+	if meta == nil {
+		return true
+	}
+	return sameOrUnknown(f.Region, meta.Region) && sameOrUnknown(f.Account, meta.Account)
+}
+
+func sameOrUnknown(value, other string) bool {
+	return value == "" || other == "" || value == other
 }
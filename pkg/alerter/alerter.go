@@ -2,67 +2,60 @@ package alerter
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/cloudskiff/driftctl/pkg/resource"
 )
 
 type Alerter struct {
-	alerts   Alerts
-	alertsCh chan Alerts
-	doneCh   chan bool
+	lock   sync.RWMutex // Alerts are read while scanning, when ignoring resources
+	alerts Alerts
 }
 
 func NewAlerter() *Alerter {
-	var alerter = &Alerter{
-		alerts:   make(Alerts),
-		alertsCh: make(chan Alerts),
-		doneCh:   make(chan bool),
-	}
-
-	go alerter.run()
-
-	return alerter
-}
-
-func (a *Alerter) run() {
-	defer func() { a.doneCh <- true }()
-	for alert := range a.alertsCh {
-		for k, v := range alert {
-			if val, ok := a.alerts[k]; ok {
-				a.alerts[k] = append(val, v...)
-			} else {
-				a.alerts[k] = v
-			}
-		}
+	return &Alerter{
+		alerts: make(Alerts),
 	}
 }
 
 func (a *Alerter) SetAlerts(alerts Alerts) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
 	a.alerts = alerts
 }
 
 func (a *Alerter) Retrieve() Alerts {
-	close(a.alertsCh)
-	<-a.doneCh
+	a.lock.RLock()
+	defer a.lock.RUnlock()
 	return a.alerts
 }
 
+// SendAlert merges the alert before returning, resources it ignores are ignored as soon as it is sent
 func (a *Alerter) SendAlert(key string, alert Alert) {
-	a.alertsCh <- Alerts{
-		key: []Alert{alert},
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.alerts == nil {
+		a.alerts = make(Alerts)
 	}
+	a.alerts[key] = append(a.alerts[key], alert)
 }
 
 func (a *Alerter) IsResourceIgnored(res resource.Resource) bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
 	alert, alertExists := a.alerts[fmt.Sprintf("%s.%s", res.TerraformType(), res.TerraformId())]
 	wildcardAlert, wildcardAlertExists := a.alerts[res.TerraformType()]
-	shouldIgnoreAlert := a.shouldBeIgnored(alert)
-	shouldIgnoreWildcardAlert := a.shouldBeIgnored(wildcardAlert)
+	shouldIgnoreAlert := a.shouldBeIgnored(res, alert)
+	shouldIgnoreWildcardAlert := a.shouldBeIgnored(res, wildcardAlert)
 	return (alertExists && shouldIgnoreAlert) || (wildcardAlertExists && shouldIgnoreWildcardAlert)
 }
 
-func (a *Alerter) shouldBeIgnored(alert []Alert) bool {
+func (a *Alerter) shouldBeIgnored(res resource.Resource, alert []Alert) bool {
 	for _, a := range alert {
+		// Scan failures only cover the region and account that could not be listed
+		if a.ScanFailure != nil && a.ScanFailure.Covers(res) {
+			return true
+		}
 		if a.ShouldIgnoreResource {
 			return true
 		}
@@ -196,6 +196,73 @@ func TestAlerter_IgnoreResources(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "TestScanFailureInAnotherRegion",
+			alerts: Alerts{
+				"fakeres": {
+					{
+						Message:     "Should not be ignored",
+						ScanFailure: &ScanFailure{ResourceType: "fakeres", Region: "ap-east-1"},
+					},
+				},
+			},
+			resource: &resource2.FakeResource{
+				Metadata: resource.Metadata{Region: "eu-west-3"},
+				Type:     "fakeres",
+				Id:       "foobar",
+			},
+			expected: false,
+		},
+		{
+			name: "TestScanFailureInSameRegion",
+			alerts: Alerts{
+				"fakeres": {
+					{
+						Message:     "Should be ignored",
+						ScanFailure: &ScanFailure{ResourceType: "fakeres", Region: "ap-east-1", Account: "123456789012"},
+					},
+				},
+			},
+			resource: &resource2.FakeResource{
+				Metadata: resource.Metadata{Region: "ap-east-1", Account: "123456789012"},
+				Type:     "fakeres",
+				Id:       "foobar",
+			},
+			expected: true,
+		},
+		{
+			name: "TestScanFailureInAnotherAccount",
+			alerts: Alerts{
+				"fakeres": {
+					{
+						Message:     "Should not be ignored",
+						ScanFailure: &ScanFailure{ResourceType: "fakeres", Region: "ap-east-1", Account: "123456789012"},
+					},
+				},
+			},
+			resource: &resource2.FakeResource{
+				Metadata: resource.Metadata{Region: "ap-east-1", Account: "210987654321"},
+				Type:     "fakeres",
+				Id:       "foobar",
+			},
+			expected: false,
+		},
+		{
+			name: "TestScanFailureOfUnknownRegion",
+			alerts: Alerts{
+				"fakeres": {
+					{
+						Message:     "Should be ignored",
+						ScanFailure: &ScanFailure{ResourceType: "fakeres", Region: "ap-east-1"},
+					},
+				},
+			},
+			resource: &resource2.FakeResource{
+				Type: "fakeres",
+				Id:   "foobar",
+			},
+			expected: true,
+		},
 	}
 
 	for _, c := range cases {
@@ -208,3 +275,16 @@ func TestAlerter_IgnoreResources(t *testing.T) {
 		})
 	}
 }
+
+func TestAlerter_SendAlertIgnoresResourceOnReturn(t *testing.T) {
+	alerter := NewAlerter()
+	res := &resource2.FakeResource{Type: "fakeres", Id: "foobar"}
+
+	alerter.SendAlert("fakeres", Alert{
+		Message:     "Should be ignored",
+		ScanFailure: &ScanFailure{ResourceType: "fakeres"},
+	})
+	if !alerter.IsResourceIgnored(res) {
+		t.Error("Resource should be ignored as soon as the alert is sent")
+	}
+}
@@ -35,6 +35,8 @@ type Analysis struct {
 	differences []Difference
 	summary     Summary
 	alerts      alerter.Alerts
+	iacSources  []string
+	options     AnalyzerOptions
 }
 
 type serializableDifference struct {
@@ -48,8 +50,14 @@ type serializableAnalysis struct {
 	Unmanaged   []resource.SerializableResource `json:"unmanaged"`
 	Deleted     []resource.SerializableResource `json:"deleted"`
 	Differences []serializableDifference        `json:"differences"`
-	Coverage    int                             `json:"coverage"`
+	Coverage    float64                         `json:"coverage"`
 	Alerts      alerter.Alerts                  `json:"alerts"`
+	IacSources  []string                        `json:"iac_sources,omitempty"`
+
+	// Breakdowns are computed from resources, they are not read back from JSON
+	CoverageByType   map[string]CoverageDetail `json:"coverage_by_type,omitempty"`
+	CoverageByRegion map[string]CoverageDetail `json:"coverage_by_region,omitempty"`
+	CoverageByState  map[string]CoverageDetail `json:"coverage_by_state,omitempty"`
 }
 
 func (a Analysis) MarshalJSON() ([]byte, error) {
@@ -71,7 +79,11 @@ func (a Analysis) MarshalJSON() ([]byte, error) {
 	}
 	bla.Summary = a.summary
 	bla.Coverage = a.Coverage()
+	bla.CoverageByType = a.CoverageByType()
+	bla.CoverageByRegion = a.CoverageByRegion()
+	bla.CoverageByState = a.CoverageByState()
 	bla.Alerts = a.alerts
+	bla.IacSources = a.iacSources
 
 	return json.Marshal(bla)
 }
@@ -82,33 +94,22 @@ func (a *Analysis) UnmarshalJSON(bytes []byte) error {
 		return err
 	}
 	for _, u := range bla.Unmanaged {
-		a.AddUnmanaged(resource.SerializedResource{
-			Id:   u.TerraformId(),
-			Type: u.TerraformType(),
-		})
+		a.AddUnmanaged(u.Resource)
 	}
 	for _, d := range bla.Deleted {
-		a.AddDeleted(resource.SerializedResource{
-			Id:   d.TerraformId(),
-			Type: d.TerraformType(),
-		})
+		a.AddDeleted(d.Resource)
 	}
 	for _, m := range bla.Managed {
-		a.AddManaged(resource.SerializedResource{
-			Id:   m.TerraformId(),
-			Type: m.TerraformType(),
-		})
+		a.AddManaged(m.Resource)
 	}
 	for _, di := range bla.Differences {
 		a.AddDifference(Difference{
-			Res: resource.SerializedResource{
-				Id:   di.Res.TerraformId(),
-				Type: di.Res.TerraformType(),
-			},
+			Res:       di.Res.Resource,
 			Changelog: di.Changelog,
 		})
 	}
 	a.SetAlerts(bla.Alerts)
+	a.SetIacSources(bla.IacSources)
 	return nil
 }
 
@@ -116,6 +117,19 @@ func (a *Analysis) IsSync() bool {
 	return a.summary.TotalDrifted == 0 && a.summary.TotalUnmanaged == 0 && a.summary.TotalDeleted == 0
 }
 
+// IsComplete returns false when resources of some types could not be listed on the cloud provider,
+// these types are then left out of the analysis
+func (a *Analysis) IsComplete() bool {
+	for _, alerts := range a.alerts {
+		for _, alert := range alerts {
+			if alert.ScanFailure != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func (a *Analysis) AddDeleted(resources ...resource.Resource) {
 	a.deleted = append(a.deleted, resources...)
 	a.summary.TotalResources += len(resources)
@@ -143,11 +157,14 @@ func (a *Analysis) SetAlerts(alerts alerter.Alerts) {
 	a.alerts = alerts
 }
 
-func (a *Analysis) Coverage() int {
-	if a.summary.TotalResources > 0 {
-		return int((float32(a.summary.TotalManaged) / float32(a.summary.TotalResources)) * 100.0)
-	}
-	return 0
+// SetIacSources records every IaC source read during the scan, once globs and prefixes are expanded
+func (a *Analysis) SetIacSources(sources []string) {
+	a.iacSources = sources
+}
+
+// Coverage returns the percentage of resources managed by IaC, rounded to two decimals
+func (a *Analysis) Coverage() float64 {
+	return coverage(a.summary.TotalManaged, a.summary.TotalResources)
 }
 
 func (a *Analysis) Managed() []resource.Resource {
@@ -173,3 +190,17 @@ func (a *Analysis) Summary() Summary {
 func (a *Analysis) Alerts() alerter.Alerts {
 	return a.alerts
 }
+
+func (a *Analysis) IacSources() []string {
+	return a.iacSources
+}
+
+// SetOptions records which parts of the analysis were skipped
+func (a *Analysis) SetOptions(options AnalyzerOptions) {
+	a.options = options
+}
+
+// Options returns what has been analyzed, e.g. differences are not computed in coverage mode
+func (a *Analysis) Options() AnalyzerOptions {
+	return a.options
+}
@@ -11,6 +11,13 @@ import (
 
 type Analyzer struct {
 	alerter *alerter.Alerter
+	options AnalyzerOptions
+}
+
+// AnalyzerOptions select what is analyzed, everything is analyzed by default
+type AnalyzerOptions struct {
+	SkipDiffs     bool // Managed resources are not compared, only their coverage is computed
+	SkipUnmanaged bool // Unmanaged resources are not reported, only managed ones are compared
 }
 
 type Filter interface {
@@ -18,12 +25,12 @@ type Filter interface {
 	IsFieldIgnored(res resource.Resource, path []string) bool
 }
 
-func NewAnalyzer(alerter *alerter.Alerter) Analyzer {
-	return Analyzer{alerter}
+func NewAnalyzer(alerter *alerter.Alerter, options AnalyzerOptions) Analyzer {
+	return Analyzer{alerter, options}
 }
 
 func (a Analyzer) Analyze(remoteResources, resourcesFromState []resource.Resource, filter Filter) (Analysis, error) {
-	analysis := Analysis{}
+	analysis := Analysis{options: a.options}
 
 	// Iterate on remote resources and filter ignored resources
 	filteredRemoteResource := make([]resource.Resource, 0, len(remoteResources))
@@ -49,8 +56,13 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []resource.Resourc
 
 		// Remove managed resources, so it will remain only unmanaged ones
 		filteredRemoteResource = removeResourceByIndex(i, filteredRemoteResource)
+		copyRemoteMetadata(stateRes, remoteRes)
 		analysis.AddManaged(stateRes)
 
+		if a.options.SkipDiffs {
+			continue
+		}
+
 		delta, _ := diff.Diff(stateRes, remoteRes)
 		if len(delta) > 0 {
 			sort.Slice(delta, func(i, j int) bool {
@@ -84,7 +96,9 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []resource.Resourc
 	}
 
 	// Add remaining unmanaged resources
-	analysis.AddUnmanaged(filteredRemoteResource...)
+	if !a.options.SkipUnmanaged {
+		analysis.AddUnmanaged(filteredRemoteResource...)
+	}
 
 	analysis.SetAlerts(a.alerter.Retrieve())
 
@@ -93,13 +107,46 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []resource.Resourc
 
 func findCorrespondingRes(resources []resource.Resource, res resource.Resource) (int, resource.Resource, bool) {
 	for i, r := range resources {
-		if resource.IsSameResource(res, r) {
+		if resource.IsSameResource(res, r) && isSameAccount(res, r) && isSameRegion(res, r) {
 			return i, r, true
 		}
 	}
 	return -1, nil, false
 }
 
+// isSameAccount returns false only when both resources are known to belong to different accounts
+func isSameAccount(res, other resource.Resource) bool {
+	meta, otherMeta := resource.GetMetadata(res), resource.GetMetadata(other)
+	if meta == nil || otherMeta == nil || meta.Account == "" || otherMeta.Account == "" {
+		return true
+	}
+	return meta.Account == otherMeta.Account
+}
+
+// isSameRegion returns false only when both resources are known to be in different regions,
+// resources named after their name (e.g. key pairs, lambda functions) may exist in several regions
+func isSameRegion(res, other resource.Resource) bool {
+	meta, otherMeta := resource.GetMetadata(res), resource.GetMetadata(other)
+	if meta == nil || otherMeta == nil || meta.Region == "" || otherMeta.Region == "" {
+		return true
+	}
+	return meta.Region == otherMeta.Region
+}
+
+// copyRemoteMetadata attributes to a resource read from IaC where it has been found on the cloud provider
+func copyRemoteMetadata(stateRes, remoteRes resource.Resource) {
+	stateMeta, remoteMeta := resource.GetMetadata(stateRes), resource.GetMetadata(remoteRes)
+	if stateMeta == nil || remoteMeta == nil {
+		return
+	}
+	if stateMeta.Region == "" {
+		stateMeta.Region = remoteMeta.Region
+	}
+	if stateMeta.Account == "" {
+		stateMeta.Account = remoteMeta.Account
+	}
+}
+
 func removeResourceByIndex(i int, resources []resource.Resource) []resource.Resource {
 	if i == len(resources)-1 {
 		return resources[:len(resources)-1]
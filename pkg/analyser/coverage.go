@@ -0,0 +1,104 @@
+package analyser
+
+import (
+	"math"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+// CoverageDetail holds the totals of a group of resources (e.g. of a type) and their coverage
+type CoverageDetail struct {
+	Summary
+	Coverage float64 `json:"coverage"`
+}
+
+// coverage returns the percentage of managed resources, rounded to two decimals for display,
+// it must not be compared as e.g. 19999 managed resources out of 20000 are rounded to 100%
+func coverage(managed, total int) float64 {
+	return math.Round(exactCoverage(managed, total)*100) / 100
+}
+
+// exactCoverage returns the percentage of managed resources, without rounding
+func exactCoverage(managed, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(managed) / float64(total) * 100
+}
+
+// CoverageByType returns the coverage of each resource type
+func (a *Analysis) CoverageByType() map[string]CoverageDetail {
+	return a.coverageBy(func(res resource.Resource) string {
+		return res.TerraformType()
+	})
+}
+
+// CoverageByRegion returns the coverage of each region, global resources and resources
+// whose region is unknown (e.g. deleted ones) are left out
+func (a *Analysis) CoverageByRegion() map[string]CoverageDetail {
+	return a.coverageBy(func(res resource.Resource) string {
+		if meta := resource.GetMetadata(res); meta != nil {
+			return meta.Region
+		}
+		return ""
+	})
+}
+
+// CoverageByState returns the coverage of each IaC source. Unmanaged resources do not belong to any source,
+// the coverage of a source is then the percentage of its resources still found on the cloud provider
+func (a *Analysis) CoverageByState() map[string]CoverageDetail {
+	return a.coverageBy(func(res resource.Resource) string {
+		if source := resource.GetSource(res); source != nil {
+			return source.State
+		}
+		return ""
+	})
+}
+
+// coverageBy groups resources by key, resources with an empty key are left out
+func (a *Analysis) coverageBy(key func(res resource.Resource) string) map[string]CoverageDetail {
+	summaries := make(map[string]*Summary)
+	summary := func(res resource.Resource) *Summary {
+		k := key(res)
+		if k == "" {
+			return nil
+		}
+		if summaries[k] == nil {
+			summaries[k] = &Summary{}
+		}
+		return summaries[k]
+	}
+
+	for _, res := range a.managed {
+		if s := summary(res); s != nil {
+			s.TotalResources++
+			s.TotalManaged++
+		}
+	}
+	for _, res := range a.unmanaged {
+		if s := summary(res); s != nil {
+			s.TotalResources++
+			s.TotalUnmanaged++
+		}
+	}
+	for _, res := range a.deleted {
+		if s := summary(res); s != nil {
+			s.TotalResources++
+			s.TotalDeleted++
+		}
+	}
+	for _, difference := range a.differences {
+		if s := summary(difference.Res); s != nil {
+			s.TotalDrifted++
+		}
+	}
+
+	details := make(map[string]CoverageDetail, len(summaries))
+	for k, s := range summaries {
+		details[k] = CoverageDetail{
+			Summary:  *s,
+			Coverage: coverage(s.TotalManaged, s.TotalResources),
+		}
+	}
+	return details
+}
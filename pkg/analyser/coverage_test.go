@@ -0,0 +1,85 @@
+package analyser
+
+import (
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	testresource "github.com/cloudskiff/driftctl/test/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalysis_CoverageBreakdown(t *testing.T) {
+	fromState := func(id, ty, state, region string) *testresource.FakeResource {
+		return &testresource.FakeResource{
+			Id:   id,
+			Type: ty,
+			Metadata: resource.Metadata{
+				Source: &resource.Source{State: state, Address: ty + "." + id},
+				Region: region,
+			},
+		}
+	}
+	fromCloud := func(id, ty, region string) *testresource.FakeResource {
+		return &testresource.FakeResource{
+			Id:       id,
+			Type:     ty,
+			Metadata: resource.Metadata{Region: region},
+		}
+	}
+
+	analysis := Analysis{}
+	analysis.AddManaged(
+		fromState("vpc-1", "aws_vpc", "tfstate://network.tfstate", "eu-west-3"),
+		fromState("vpc-2", "aws_vpc", "tfstate://network.tfstate", "us-east-1"),
+		fromState("bucket-1", "aws_s3_bucket", "tfstate://storage.tfstate", "eu-west-3"),
+		fromState("role-1", "aws_iam_role", "tfstate://storage.tfstate", ""),
+	)
+	analysis.AddUnmanaged(
+		fromCloud("vpc-3", "aws_vpc", "eu-west-3"),
+		fromCloud("bucket-2", "aws_s3_bucket", "eu-west-3"),
+		fromCloud("bucket-3", "aws_s3_bucket", "us-east-1"),
+	)
+	analysis.AddDeleted(fromState("bucket-4", "aws_s3_bucket", "tfstate://storage.tfstate", ""))
+	analysis.AddDifference(Difference{Res: analysis.Managed()[0]})
+
+	assert.Equal(t, 50.0, analysis.Coverage())
+
+	assert.Equal(t, map[string]CoverageDetail{
+		"aws_vpc": {
+			Summary:  Summary{TotalResources: 3, TotalManaged: 2, TotalUnmanaged: 1, TotalDrifted: 1},
+			Coverage: 66.67,
+		},
+		"aws_s3_bucket": {
+			Summary:  Summary{TotalResources: 4, TotalManaged: 1, TotalUnmanaged: 2, TotalDeleted: 1},
+			Coverage: 25,
+		},
+		"aws_iam_role": {
+			Summary:  Summary{TotalResources: 1, TotalManaged: 1},
+			Coverage: 100,
+		},
+	}, analysis.CoverageByType())
+
+	// Global and deleted resources are not attributed to any region
+	assert.Equal(t, map[string]CoverageDetail{
+		"eu-west-3": {
+			Summary:  Summary{TotalResources: 4, TotalManaged: 2, TotalUnmanaged: 2, TotalDrifted: 1},
+			Coverage: 50,
+		},
+		"us-east-1": {
+			Summary:  Summary{TotalResources: 2, TotalManaged: 1, TotalUnmanaged: 1},
+			Coverage: 50,
+		},
+	}, analysis.CoverageByRegion())
+
+	// Unmanaged resources do not belong to any state
+	assert.Equal(t, map[string]CoverageDetail{
+		"tfstate://network.tfstate": {
+			Summary:  Summary{TotalResources: 2, TotalManaged: 2, TotalDrifted: 1},
+			Coverage: 100,
+		},
+		"tfstate://storage.tfstate": {
+			Summary:  Summary{TotalResources: 3, TotalManaged: 2, TotalDeleted: 1},
+			Coverage: 66.67,
+		},
+	}, analysis.CoverageByState())
+}
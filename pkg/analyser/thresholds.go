@@ -0,0 +1,94 @@
+package analyser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Categories of drift a scan can fail on
+const (
+	CategoryDeleted   = "deleted"
+	CategoryDrifted   = "drifted"
+	CategoryUnmanaged = "unmanaged"
+)
+
+// Categories returns every category of drift
+func Categories() []string {
+	return []string{CategoryDeleted, CategoryDrifted, CategoryUnmanaged}
+}
+
+// IsCategory returns true when the category of drift exists
+func IsCategory(category string) bool {
+	for _, c := range Categories() {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Thresholds decide whether the drift found by an analysis fails the scan
+type Thresholds struct {
+	Categories  []string // Categories of drift failing the scan, drift is tolerated when empty
+	MaxDrift    int      // Number of resources in these categories tolerated
+	MinCoverage float64  // Minimum percentage of resources managed by IaC, not checked when zero
+}
+
+// Failures returns the reasons the analysis exceeds thresholds, it is empty when the analysis is within thresholds
+func (t Thresholds) Failures(a *Analysis) []string {
+	failures := make([]string, 0)
+
+	counts := make([]string, 0, len(t.Categories))
+	total := 0
+	for _, category := range Categories() {
+		if !t.failsOn(category) {
+			continue
+		}
+		count := a.count(category)
+		total += count
+		if count > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", count, category))
+		}
+	}
+	if total > t.MaxDrift {
+		failure := fmt.Sprintf("drift found: %s resource(s)", strings.Join(counts, ", "))
+		if t.MaxDrift > 0 {
+			failure += fmt.Sprintf(", more than the %d tolerated", t.MaxDrift)
+		}
+		failures = append(failures, failure)
+	}
+
+	// Coverage of an empty scan is meaningless
+	if t.MinCoverage > 0 && a.summary.TotalResources > 0 && exactCoverage(a.summary.TotalManaged, a.summary.TotalResources) < t.MinCoverage {
+		failures = append(failures, fmt.Sprintf(
+			"coverage is %g%% (%d/%d resources managed), below the minimum of %g%%",
+			a.Coverage(),
+			a.summary.TotalManaged,
+			a.summary.TotalResources,
+			t.MinCoverage,
+		))
+	}
+
+	return failures
+}
+
+func (t Thresholds) failsOn(category string) bool {
+	for _, c := range t.Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Analysis) count(category string) int {
+	switch category {
+	case CategoryDeleted:
+		return a.summary.TotalDeleted
+	case CategoryDrifted:
+		return a.summary.TotalDrifted
+	case CategoryUnmanaged:
+		return a.summary.TotalUnmanaged
+	}
+	return 0
+}
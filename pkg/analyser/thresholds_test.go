@@ -0,0 +1,100 @@
+package analyser
+
+import (
+	"fmt"
+	"testing"
+
+	testresource "github.com/cloudskiff/driftctl/test/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThresholds_Failures(t *testing.T) {
+	analysis := Analysis{}
+	analysis.AddManaged(
+		&testresource.FakeResource{Id: "managed-1"},
+		&testresource.FakeResource{Id: "managed-2"},
+		&testresource.FakeResource{Id: "managed-3"},
+		&testresource.FakeResource{Id: "managed-4"},
+		&testresource.FakeResource{Id: "managed-5"},
+	)
+	analysis.AddUnmanaged(&testresource.FakeResource{Id: "unmanaged-1"}, &testresource.FakeResource{Id: "unmanaged-2"})
+	analysis.AddDeleted(&testresource.FakeResource{Id: "deleted-1"})
+	analysis.AddDifference(Difference{Res: &testresource.FakeResource{Id: "managed-1"}})
+
+	// Coverage is displayed as 100% but one resource is not managed
+	almostCovered := Analysis{}
+	for i := 0; i < 19999; i++ {
+		almostCovered.AddManaged(&testresource.FakeResource{Id: fmt.Sprintf("managed-%d", i)})
+	}
+	almostCovered.AddUnmanaged(&testresource.FakeResource{Id: "unmanaged-1"})
+
+	tests := []struct {
+		name       string
+		thresholds Thresholds
+		analysis   Analysis
+		expected   []string
+	}{
+		{
+			name:       "every category",
+			thresholds: Thresholds{Categories: Categories()},
+			analysis:   analysis,
+			expected:   []string{"drift found: 1 deleted, 1 drifted, 2 unmanaged resource(s)"},
+		},
+		{
+			name:       "unmanaged resources only",
+			thresholds: Thresholds{Categories: []string{CategoryUnmanaged}},
+			analysis:   analysis,
+			expected:   []string{"drift found: 2 unmanaged resource(s)"},
+		},
+		{
+			name:       "within threshold",
+			thresholds: Thresholds{Categories: []string{CategoryDeleted, CategoryUnmanaged}, MaxDrift: 3},
+			analysis:   analysis,
+			expected:   []string{},
+		},
+		{
+			name:       "above threshold",
+			thresholds: Thresholds{Categories: Categories(), MaxDrift: 3},
+			analysis:   analysis,
+			expected:   []string{"drift found: 1 deleted, 1 drifted, 2 unmanaged resource(s), more than the 3 tolerated"},
+		},
+		{
+			name:       "coverage below minimum",
+			thresholds: Thresholds{MinCoverage: 75},
+			analysis:   analysis,
+			expected:   []string{"coverage is 62.5% (5/8 resources managed), below the minimum of 75%"},
+		},
+		{
+			name:       "coverage above minimum",
+			thresholds: Thresholds{MinCoverage: 60},
+			analysis:   analysis,
+			expected:   []string{},
+		},
+		{
+			name:       "coverage rounded to minimum",
+			thresholds: Thresholds{MinCoverage: 100},
+			analysis:   almostCovered,
+			expected:   []string{"coverage is 100% (19999/20000 resources managed), below the minimum of 100%"},
+		},
+		{
+			name:       "coverage of empty analysis",
+			thresholds: Thresholds{Categories: Categories(), MinCoverage: 60},
+			analysis:   Analysis{},
+			expected:   []string{},
+		},
+		{
+			name:       "drift and coverage",
+			thresholds: Thresholds{Categories: []string{CategoryDeleted}, MinCoverage: 75},
+			analysis:   analysis,
+			expected: []string{
+				"drift found: 1 deleted resource(s)",
+				"coverage is 62.5% (5/8 resources managed), below the minimum of 75%",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.thresholds.Failures(&tt.analysis))
+		})
+	}
+}
@@ -66,6 +66,7 @@ func NewDriftctlCmd(build build.BuildInterface) *DriftctlCmd {
 	cmd.PersistentFlags().BoolP("error-reporting", "", false, "Enable error reporting.\nWARNING: may leak sensitive data")
 
 	cmd.AddCommand(NewScanCmd())
+	cmd.AddCommand(NewGenDriftIgnoreCmd())
 
 	return cmd
 }
@@ -0,0 +1,23 @@
+package cmd
+
+// Exit codes of driftctl, a scan exits with ExitCodeInSync when drift is within thresholds
+const (
+	ExitCodeInSync         = 0
+	ExitCodeError          = 1
+	ExitCodeDriftFound     = 2
+	ExitCodeScanIncomplete = 3 // Some resource types could not be listed, it takes precedence over drift
+)
+
+// ExitError is returned by commands that have written their output but should not exit successfully
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e ExitError) Unwrap() error {
+	return e.Err
+}
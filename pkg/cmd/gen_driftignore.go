@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type GenDriftIgnoreOptions struct {
+	Input      string // JSON analysis to read, a scan is run when empty
+	Categories []string
+	Wildcards  []string
+}
+
+func NewGenDriftIgnoreCmd() *cobra.Command {
+	opts := &GenDriftIgnoreOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "gen-driftignore [flags] [-- scan flags]",
+		Short: "Generate .driftignore lines",
+		Long: "Generate .driftignore lines ignoring the drift of a JSON analysis written by 'driftctl scan --output json://PATH',\n" +
+			"or of a scan run with the flags given after --",
+		Args: cobra.ArbitraryArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Input != "" && len(args) > 0 {
+				return errors.New("scan flags cannot be used with --input")
+			}
+			for _, category := range opts.Categories {
+				if !analyser.IsCategory(category) {
+					return fmt.Errorf(
+						"unsupported drift category '%s' in --categories\nValid values are: %s",
+						category,
+						strings.Join(analyser.Categories(), ","),
+					)
+				}
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return genDriftIgnoreRun(cmd, opts, args)
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVarP(
+		&opts.Input,
+		"input",
+		"i",
+		"",
+		"JSON analysis to read, as written by 'driftctl scan --output json://PATH'\n"+
+			"A scan is run with the flags given after -- when not set\n",
+	)
+	fl.StringSliceVar(
+		&opts.Categories,
+		"categories",
+		analyser.Categories(),
+		"Categories of drift to ignore among "+strings.Join(analyser.Categories(), ", ")+"\n",
+	)
+	fl.StringSliceVar(
+		&opts.Wildcards,
+		"wildcard-types",
+		[]string{},
+		"Resource types ignored with a single type.* line instead of one line per resource, wildcards are accepted\n"+
+			"Example: --wildcard-types aws_iam_*,aws_s3_bucket\n",
+	)
+
+	return cmd
+}
+
+func genDriftIgnoreRun(cmd *cobra.Command, opts *GenDriftIgnoreOptions, scanArgs []string) error {
+	generator, err := filter.NewDriftIgnoreGenerator(opts.Categories, opts.Wildcards)
+	if err != nil {
+		return err
+	}
+
+	input := opts.Input
+	if input == "" {
+		file, err := ioutil.TempFile("", "driftctl-analysis-*.json")
+		if err != nil {
+			return err
+		}
+		file.Close()
+		defer os.Remove(file.Name())
+
+		if err := runScanToFile(scanArgs, file.Name()); err != nil {
+			return err
+		}
+		input = file.Name()
+	}
+
+	analysis, err := readAnalysis(input)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range generator.Lines(analysis) {
+		fmt.Fprintln(cmd.OutOrStdout(), line)
+	}
+	return nil
+}
+
+// runScanToFile writes the JSON analysis of a scan to a file, drift does not fail the scan as it is what is ignored
+func runScanToFile(args []string, path string) error {
+	scanCmd := NewScanCmd()
+	scanCmd.SilenceUsage = true
+	scanCmd.SilenceErrors = true
+	scanCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return bindEnvToFlags(cmd)
+	}
+	scanCmd.SetArgs(append(append([]string{}, args...), "--output", "json://"+path))
+
+	err := scanCmd.Execute()
+	var exitErr ExitError
+	if errors.As(err, &exitErr) && exitErr.Code != ExitCodeError {
+		if exitErr.Code == ExitCodeScanIncomplete {
+			logrus.Warn("Scan is incomplete, resources of types that could not be listed are not ignored")
+		}
+		return nil
+	}
+	return err
+}
+
+func readAnalysis(path string) (*analyser.Analysis, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	analysis := &analyser.Analysis{}
+	if err := json.Unmarshal(content, analysis); err != nil {
+		return nil, fmt.Errorf("unable to read analysis from %s: %w", path, err)
+	}
+	return analysis, nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/test"
+	testresource "github.com/cloudskiff/driftctl/test/resource"
+	"github.com/r3labs/diff/v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFakeAnalysis(t *testing.T) string {
+	a := &analyser.Analysis{}
+	a.AddUnmanaged(
+		&testresource.FakeResource{Type: "aws_s3_bucket", Id: "bucket.example.com"},
+		&testresource.FakeResource{Type: "aws_iam_user", Id: "user-1"},
+	)
+	a.AddDeleted(&testresource.FakeResource{Type: "aws_instance", Id: "i-0123456789"})
+	drifted := &testresource.FakeResource{Type: "aws_iam_role", Id: "role-1"}
+	a.AddManaged(drifted)
+	a.AddDifference(analyser.Difference{Res: drifted, Changelog: []analyser.Change{
+		{Change: diff.Change{Type: diff.UPDATE, Path: []string{"Tags", "kubernetes.io/role"}, From: "a", To: "b"}},
+	}})
+
+	content, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := path.Join(t.TempDir(), "analysis.json")
+	if err := ioutil.WriteFile(file, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestGenDriftIgnoreCmd(t *testing.T) {
+	input := writeFakeAnalysis(t)
+
+	cases := []struct {
+		args     []string
+		expected []string
+	}{
+		{
+			args: []string{"gen-driftignore", "-i", input},
+			expected: []string{
+				`aws_iam_role.role-1.Tags.kubernetes\.io/role`,
+				"aws_iam_user.user-1",
+				"aws_instance.i-0123456789",
+				`aws_s3_bucket.bucket\.example\.com`,
+			},
+		},
+		{
+			args: []string{"gen-driftignore", "--input", input, "--categories", "unmanaged", "--wildcard-types", "aws_iam_*"},
+			expected: []string{
+				"aws_iam_user.*",
+				`aws_s3_bucket.bucket\.example\.com`,
+			},
+		},
+		{
+			args:     []string{"gen-driftignore", "--input", input, "--categories", "deleted,drifted", "--wildcard-types", "aws_iam_role"},
+			expected: []string{"aws_iam_role.*.Tags.kubernetes\\.io/role", "aws_instance.i-0123456789"},
+		},
+		{
+			args:     []string{"gen-driftignore", "--input", input, "--categories", ""},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range cases {
+		rootCmd := &cobra.Command{Use: "root"}
+		rootCmd.AddCommand(NewGenDriftIgnoreCmd())
+
+		output, err := test.Execute(rootCmd, tt.args...)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+		if output == "" {
+			lines = []string{}
+		}
+		assert.Equal(t, tt.expected, lines)
+	}
+}
+
+func TestGenDriftIgnoreCmd_Invalid(t *testing.T) {
+	input := writeFakeAnalysis(t)
+	missing := path.Join(path.Dir(input), "missing.json")
+
+	cases := []struct {
+		args     []string
+		expected string
+	}{
+		{args: []string{"gen-driftignore", "--input", input, "--", "--from", "tfstate://terraform.tfstate"}, expected: "scan flags cannot be used with --input"},
+		{args: []string{"gen-driftignore", "--input", input, "--categories", "missing"}, expected: "unsupported drift category 'missing' in --categories\nValid values are: deleted,drifted,unmanaged"},
+		{args: []string{"gen-driftignore", "--input", input, "--wildcard-types", "aws_["}, expected: "invalid resource type pattern aws_[: syntax error in pattern"},
+		{args: []string{"gen-driftignore", "--input", missing}, expected: "open " + missing + ": no such file or directory"},
+		{args: []string{"gen-driftignore", "--", "--to", "test"}, expected: "unsupported cloud provider 'test'\nValid values are: aws+tf"},
+	}
+
+	for _, tt := range cases {
+		rootCmd := &cobra.Command{Use: "root"}
+		rootCmd.AddCommand(NewGenDriftIgnoreCmd())
+
+		_, err := test.Execute(rootCmd, tt.args...)
+		if err == nil {
+			t.Errorf("Invalid arg should generate error")
+			continue
+		}
+		if err.Error() != tt.expected {
+			t.Errorf("Expected '%v', got '%v'", tt.expected, err)
+		}
+	}
+}
@@ -7,16 +7,22 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/cloudskiff/driftctl/pkg"
 	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/analyser"
 	"github.com/cloudskiff/driftctl/pkg/cmd/scan/output"
 	"github.com/cloudskiff/driftctl/pkg/filter"
 	"github.com/cloudskiff/driftctl/pkg/iac/config"
 	"github.com/cloudskiff/driftctl/pkg/iac/supplier"
 	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/cloudskiff/driftctl/pkg/parallel"
+	"github.com/cloudskiff/driftctl/pkg/progress"
 	"github.com/cloudskiff/driftctl/pkg/remote"
+	"github.com/cloudskiff/driftctl/pkg/remote/aws"
 	"github.com/cloudskiff/driftctl/pkg/resource"
+	resourceaws "github.com/cloudskiff/driftctl/pkg/resource/aws"
 	"github.com/cloudskiff/driftctl/pkg/terraform"
 	"github.com/jmespath/go-jmespath"
 	"github.com/sirupsen/logrus"
@@ -24,12 +30,20 @@ import (
 )
 
 type ScanOptions struct {
-	Coverage bool
-	Detect   bool
+	Coverage bool // Resources are only listed to compute the coverage of IaC, they are neither read nor compared
+	Detect   bool // Unmanaged resources are hidden from the output, every resource is still listed and read
 	From     []config.SupplierConfig
 	To       string
+	AWS      aws.Options
 	Output   output.OutputConfig
+	Imports  string // Path of the Terraform configuration generated to import unmanaged resources
 	Filter   *jmespath.JMESPath
+	Types    *filter.TypeFilter
+
+	Thresholds analyser.Thresholds
+
+	Parallelism    int
+	IacParallelism int
 }
 
 func NewScanCmd() *cobra.Command {
@@ -48,6 +62,20 @@ func NewScanCmd() *cobra.Command {
 				return err
 			}
 
+			workspace, _ := cmd.Flags().GetString("tf-workspace")
+			workspaceKeyPrefix, _ := cmd.Flags().GetString("tf-workspace-key-prefix")
+			for i := range iacSource {
+				if backend.SupportsWorkspaces(iacSource[i].Backend) {
+					iacSource[i].Workspace = workspace
+					iacSource[i].WorkspaceKeyPrefix = workspaceKeyPrefix
+				}
+			}
+
+			fromAccount, _ := cmd.Flags().GetStringSlice("from-account")
+			if err := parseFromAccountFlag(fromAccount, iacSource); err != nil {
+				return err
+			}
+
 			opts.From = iacSource
 
 			to, _ := cmd.Flags().GetString("to")
@@ -59,6 +87,62 @@ func NewScanCmd() *cobra.Command {
 				)
 			}
 
+			if opts.AWS.Provider.Version == "" {
+				lockFile, _ := cmd.Flags().GetString("tf-lockfile")
+				version, err := terraform.ProviderVersionFromLockFile(lockFile, terraform.AWS)
+				if err != nil && (cmd.Flags().Changed("tf-lockfile") || !errors.Is(err, os.ErrNotExist)) {
+					return err
+				}
+				opts.AWS.Provider.Version = version
+				// Hashes are only locked for the version of the lock file
+				hashes, _ := terraform.ProviderHashesFromLockFile(lockFile, terraform.AWS)
+				opts.AWS.Provider.Hashes = hashes
+			}
+
+			if opts.Parallelism < 1 || opts.AWS.ReadParallelism < 1 {
+				return errors.New("--parallelism and --aws-read-parallelism must be at least 1")
+			}
+			if opts.IacParallelism < 0 {
+				return errors.New("--iac-parallelism cannot be negative")
+			}
+			if opts.AWS.ReadCache.Refresh && !opts.AWS.ReadCache.Enabled() {
+				return errors.New("--refresh-cache requires the cache to be enabled with --cache-ttl")
+			}
+
+			if opts.Coverage && opts.Detect {
+				return errors.New("--coverage-only and --detect-only cannot be used together")
+			}
+			if opts.Detect && opts.Thresholds.MinCoverage > 0 {
+				return errors.New("--min-coverage cannot be used with --detect-only as unmanaged resources are not reported")
+			}
+			if opts.Detect && opts.Imports != "" {
+				return errors.New("--generate-imports cannot be used with --detect-only as unmanaged resources are not reported")
+			}
+			if opts.Coverage && opts.Imports != "" {
+				return errors.New("--generate-imports cannot be used with --coverage-only as resources are not read")
+			}
+			opts.AWS.ListOnly = opts.Coverage
+
+			if len(opts.AWS.AllowedAccountIds) > 0 && len(opts.AWS.ForbiddenAccountIds) > 0 {
+				return errors.New("--allowed-account-ids and --forbidden-account-ids cannot be used together")
+			}
+
+			for _, category := range opts.Thresholds.Categories {
+				if !analyser.IsCategory(category) {
+					return fmt.Errorf(
+						"unsupported drift category '%s' in --fail-on\nValid values are: %s",
+						category,
+						strings.Join(analyser.Categories(), ","),
+					)
+				}
+			}
+			if opts.Thresholds.MaxDrift < 0 {
+				return errors.New("--fail-threshold cannot be negative")
+			}
+			if opts.Thresholds.MinCoverage < 0 || opts.Thresholds.MinCoverage > 100 {
+				return errors.New("--min-coverage must be a percentage between 0 and 100")
+			}
+
 			outputFlag, _ := cmd.Flags().GetString("output")
 			out, err := parseOutputFlag(outputFlag)
 			if err != nil {
@@ -75,6 +159,19 @@ func NewScanCmd() *cobra.Command {
 				opts.Filter = expr
 			}
 
+			includeTypes, _ := cmd.Flags().GetStringSlice("include-types")
+			excludeTypes, _ := cmd.Flags().GetStringSlice("exclude-types")
+			if len(includeTypes) > 0 || len(excludeTypes) > 0 {
+				types, err := parseTypesFlags(includeTypes, excludeTypes)
+				if err != nil {
+					return err
+				}
+				opts.Types = types
+				opts.AWS.Types = types
+			}
+
+			// Flags are valid, errors of the scan itself (e.g. drift found) are not about how the command is used
+			cmd.SilenceUsage = true
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -93,6 +190,17 @@ func NewScanCmd() *cobra.Command {
 			"  - Type =='aws_s3_bucket && Id != 'my_bucket' (excludes s3 bucket 'my_bucket')\n"+
 			"  - Attr.Tags.Terraform == 'true' (include only resources that have Tag Terraform equal to 'true')\n",
 	)
+	fl.StringSlice(
+		"include-types",
+		[]string{},
+		"Resource types to scan, others are neither listed on the cloud provider nor read from IaC\n"+
+			"Wildcards are accepted, example: --include-types 'aws_s3_*,aws_iam_user'\n",
+	)
+	fl.StringSlice(
+		"exclude-types",
+		[]string{},
+		"Resource types not to scan, wildcards are accepted\n",
+	)
 	fl.StringP(
 		"output",
 		"o",
@@ -100,6 +208,13 @@ func NewScanCmd() *cobra.Command {
 		"Output format, by default it will write to the console\n"+
 			"Accepted formats are: "+strings.Join(output.SupportedOutputsExample(), ",")+"\n",
 	)
+	fl.StringVar(
+		&opts.Imports,
+		"generate-imports",
+		"",
+		"Write a Terraform configuration with import blocks and resource skeletons of unmanaged resources to this file\n"+
+			"Example: --generate-imports imports.tf\n",
+	)
 	fl.StringSliceP(
 		"from",
 		"f",
@@ -107,6 +222,17 @@ func NewScanCmd() *cobra.Command {
 		"IaC sources, by default try to find local terraform.tfstate file\n"+
 			"Accepted schemes are: "+strings.Join(supplier.GetSupportedSchemes(), ",")+"\n",
 	)
+	fl.String(
+		"tf-workspace",
+		"",
+		"Terraform workspace to read from S3 states, by default the state key is read as is\n"+
+			"Use '"+backend.AllWorkspaces+"' to read every workspace found for the state key\n",
+	)
+	fl.String(
+		"tf-workspace-key-prefix",
+		backend.DefaultWorkspaceKeyPrefix,
+		"Prefix of the keys of non-default workspaces in S3 states (workspace_key_prefix of the Terraform S3 backend)\n",
+	)
 	supportedRemotes := remote.GetSupportedRemotes()
 	fl.StringVarP(
 		&opts.To,
@@ -116,6 +242,191 @@ func NewScanCmd() *cobra.Command {
 		"Cloud provider source\n"+
 			"Accepted values are: "+strings.Join(supportedRemotes, ",")+"\n",
 	)
+	fl.StringSliceVar(
+		&opts.AWS.Regions,
+		"regions",
+		[]string{},
+		"AWS regions to scan, by default the region of your AWS configuration is scanned\n"+
+			"Use '"+aws.AllRegions+"' to scan every region enabled for your account\n"+
+			"Global services (IAM, Route53, S3) are scanned once\n",
+	)
+	fl.StringVar(
+		&opts.AWS.Profile,
+		"aws-profile",
+		"",
+		"Named profile of your AWS configuration to use, by default AWS_PROFILE or the default profile is used\n",
+	)
+	fl.StringSliceVar(
+		&opts.AWS.AssumeRoles,
+		"assume-roles",
+		[]string{},
+		"ARN of AWS roles to assume, one per account to scan\n"+
+			"By default only the account of your AWS configuration is scanned\n",
+	)
+	fl.StringVar(
+		&opts.AWS.AssumeRoleExternalID,
+		"assume-role-external-id",
+		"",
+		"External ID to use when assuming roles given with --assume-roles\n",
+	)
+	fl.StringVar(
+		&opts.AWS.AssumeRoleSessionName,
+		"assume-role-session-name",
+		"driftctl",
+		"Session name to use when assuming roles given with --assume-roles\n",
+	)
+	fl.BoolVar(
+		&opts.AWS.AssumeRoleReadOnly,
+		"assume-role-read-only",
+		false,
+		"Restrict sessions of roles given with --assume-roles to read-only actions (Describe*, List*, Get*) with a session policy\n"+
+			"It applies to calls of driftctl and of the Terraform AWS provider\n",
+	)
+	fl.StringSliceVar(
+		&opts.AWS.AllowedAccountIds,
+		"allowed-account-ids",
+		[]string{},
+		"AWS account IDs allowed to be scanned, the scan is refused for any other account\n",
+	)
+	fl.StringSliceVar(
+		&opts.AWS.ForbiddenAccountIds,
+		"forbidden-account-ids",
+		[]string{},
+		"AWS account IDs not allowed to be scanned\n",
+	)
+	fl.IntVar(
+		&opts.AWS.MaxRetries,
+		"aws-max-retries",
+		10,
+		"Maximum number of retries of AWS API calls\n",
+	)
+	fl.BoolVar(
+		&opts.Coverage,
+		"coverage-only",
+		false,
+		"Only list resources on the cloud provider to compute the coverage of IaC\n"+
+			"Resources are not read nor compared, which makes the scan much faster\n",
+	)
+	fl.BoolVar(
+		&opts.Detect,
+		"detect-only",
+		false,
+		"Only report drift of resources managed by IaC, unmanaged resources are hidden from the output\n"+
+			"Every resource is still listed and read on the cloud provider, the scan is not faster\n",
+	)
+	fl.StringSliceVar(
+		&opts.Thresholds.Categories,
+		"fail-on",
+		analyser.Categories(),
+		"Categories of drift failing the scan with exit code 2, among: "+strings.Join(analyser.Categories(), ",")+"\n"+
+			"Use --fail-on '' to never fail on drift\n",
+	)
+	fl.IntVar(
+		&opts.Thresholds.MaxDrift,
+		"fail-threshold",
+		0,
+		"Number of resources in --fail-on categories tolerated before failing the scan\n",
+	)
+	fl.Float64Var(
+		&opts.Thresholds.MinCoverage,
+		"min-coverage",
+		0,
+		"Fail the scan with exit code 2 when the percentage of resources managed by IaC is below this minimum\n",
+	)
+	fl.IntVar(
+		&opts.Parallelism,
+		"parallelism",
+		parallel.DefaultMaxRun,
+		"Number of resource types listed at once on the cloud provider\n",
+	)
+	fl.IntVar(
+		&opts.AWS.ReadParallelism,
+		"aws-read-parallelism",
+		parallel.DefaultMaxRun,
+		"Number of resources read at once through the Terraform AWS provider\n"+
+			"It is lowered while AWS throttles requests and raised back once they succeed again\n",
+	)
+	fl.DurationVar(
+		&opts.AWS.ReadCache.TTL,
+		"cache-ttl",
+		0,
+		"Cache resources read on the cloud provider on disk and use them for this long, e.g. 30m or 2h\n"+
+			"Reads are not cached by default\n",
+	)
+	fl.StringVar(
+		&opts.AWS.ReadCache.Dir,
+		"cache-dir",
+		"",
+		"Directory resources read on the cloud provider are cached in, by default ~/.driftctl/cache\n",
+	)
+	fl.BoolVar(
+		&opts.AWS.ReadCache.Refresh,
+		"refresh-cache",
+		false,
+		"Read every resource on the cloud provider again and refresh the cache\n",
+	)
+	fl.IntVar(
+		&opts.IacParallelism,
+		"iac-parallelism",
+		0,
+		"Number of IaC sources read at once, by default as many as CPUs\n",
+	)
+	fl.StringToStringVar(
+		&opts.AWS.Endpoints,
+		"aws-endpoints",
+		map[string]string{},
+		"Custom endpoints of AWS services, used to scan AWS emulators like LocalStack\n"+
+			"The s3 endpoint is also used to read states from the S3 backend\n"+
+			"Example: --aws-endpoints ec2=http://localhost:4566,s3=http://localhost:4566\n",
+	)
+	fl.StringVar(
+		&opts.AWS.Provider.ProviderPath,
+		"provider-path",
+		"",
+		"Path of the Terraform AWS provider binary to use instead of downloading it\n",
+	)
+	fl.StringVar(
+		&opts.AWS.Provider.Version,
+		"provider-version",
+		"",
+		"Version of the Terraform AWS provider to use, by default the version locked in the lock file or "+terraform.DefaultAwsProviderVersion+"\n",
+	)
+	fl.String(
+		"tf-lockfile",
+		terraform.DefaultLockFile,
+		"Terraform dependency lock file to read the version of the AWS provider from\n",
+	)
+	fl.StringVar(
+		&opts.AWS.Provider.PluginDir,
+		"plugin-dir",
+		"",
+		"Filesystem mirror of Terraform providers to install the AWS provider from instead of downloading it\n"+
+			"Both layouts created by 'terraform providers mirror' are supported\n",
+	)
+	fl.BoolVar(
+		&opts.AWS.Provider.AllowUnverified,
+		"allow-unverified-provider",
+		false,
+		"Use a provider of the plugin directory even though no hash of it is locked in the dependency lock file\n",
+	)
+	fl.StringVar(
+		&opts.AWS.Provider.MirrorURL,
+		"provider-mirror-url",
+		terraform.DefaultProviderMirrorURL,
+		"Base URL of a mirror of "+terraform.DefaultProviderMirrorURL+" to download the Terraform AWS provider from\n",
+	)
+	fl.BoolVar(
+		&opts.AWS.S3ForcePathStyle,
+		"aws-s3-force-path-style",
+		false,
+		"Use path-style addressing of S3 buckets (http://endpoint/BUCKET/KEY), required by most S3 emulators\n",
+	)
+	fl.StringSlice(
+		"from-account",
+		[]string{},
+		"Account ID of the resources of an IaC source, used to compare them only with resources of this account\n"+
+			"Example: --from-account tfstate+s3://bucket/prod.tfstate=123456789012\n",
+	)
 
 	return cmd
 }
@@ -126,7 +437,9 @@ func scanRun(opts *ScanOptions) error {
 
 	alerter := alerter.NewAlerter()
 
-	err := remote.Activate(opts.To, alerter)
+	backend.SetS3Endpoint(opts.AWS.Endpoints["s3"], opts.AWS.S3ForcePathStyle)
+
+	err := remote.Activate(opts.To, alerter, opts.AWS)
 	if err != nil {
 		return err
 	}
@@ -138,13 +451,21 @@ func scanRun(opts *ScanOptions) error {
 		logrus.Trace("Exited")
 	}()
 
-	scanner := pkg.NewScanner(resource.Suppliers(), alerter)
+	scanner := pkg.NewScanner(resource.Suppliers(), alerter, opts.Parallelism)
+
+	from, err := supplier.ExpandSupplierConfigs(opts.From)
+	if err != nil {
+		return err
+	}
 
-	iacSupplier, err := supplier.GetIACSupplier(opts.From)
+	iacSupplier, err := supplier.GetIACSupplier(from, opts.IacParallelism)
 	if err != nil {
 		return err
 	}
-	ctl := pkg.NewDriftCTL(scanner, iacSupplier, opts.Filter, alerter)
+	ctl := pkg.NewDriftCTL(scanner, iacSupplier, opts.Filter, opts.Types, alerter, analyser.AnalyzerOptions{
+		SkipDiffs:     opts.Coverage,
+		SkipUnmanaged: opts.Detect,
+	})
 
 	go func() {
 		<-c
@@ -152,13 +473,73 @@ func scanRun(opts *ScanOptions) error {
 		ctl.Stop()
 	}()
 
+	// Progress would be mixed up with logs, it is only displayed on terminals when logs are quiet
+	var display *progress.Display
+	if progress.IsTerminal(os.Stderr) && !logrus.IsLevelEnabled(logrus.InfoLevel) {
+		display = progress.NewDisplay(os.Stderr)
+		progress.AddReporter(display)
+		display.Start(200 * time.Millisecond)
+	}
+
 	analysis := ctl.Run()
 
+	// Progress is erased before the output is written
+	if display != nil {
+		progress.RemoveReporter(display)
+		display.Stop()
+	}
+
 	if analysis == nil {
 		return errors.New("unable to run driftctl")
 	}
+
+	iacSources := make([]string, 0, len(from))
+	for _, cfg := range from {
+		iacSources = append(iacSources, cfg.String())
+	}
+	analysis.SetIacSources(iacSources)
+
 	out := output.GetOutput(opts.Output)
-	return out.Write(analysis)
+	if err := out.Write(analysis); err != nil {
+		return err
+	}
+
+	if opts.Imports != "" {
+		if err := output.NewImports(opts.Imports, terraform.Provider(terraform.AWS)).Write(analysis); err != nil {
+			return err
+		}
+	}
+
+	if !analysis.IsComplete() {
+		return ExitError{
+			Code: ExitCodeScanIncomplete,
+			Err:  errors.New("scan is incomplete, some resource types could not be listed"),
+		}
+	}
+	// Thresholds are checked on the analysis, whatever the output format
+	if failures := opts.Thresholds.Failures(analysis); len(failures) > 0 {
+		return ExitError{
+			Code: ExitCodeDriftFound,
+			Err:  errors.New(strings.Join(failures, "\n")),
+		}
+	}
+	return nil
+}
+
+func parseTypesFlags(include, exclude []string) (*filter.TypeFilter, error) {
+	types, err := filter.NewTypeFilter(include, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	supportedTypes := make([]string, 0)
+	for _, res := range resourceaws.Resources() {
+		supportedTypes = append(supportedTypes, res.TerraformType())
+	}
+	if unknown := types.UnknownPatterns(supportedTypes); len(unknown) > 0 {
+		return nil, fmt.Errorf("Unsupported resource type(s) in --include-types or --exclude-types: %s", strings.Join(unknown, ", "))
+	}
+	return types, nil
 }
 
 func parseFromFlag(from []string) ([]config.SupplierConfig, error) {
@@ -217,6 +598,29 @@ func parseFromFlag(from []string) ([]config.SupplierConfig, error) {
 	return configs, nil
 }
 
+// parseFromAccountFlag sets the account of IaC sources from FROM=ACCOUNT_ID values
+func parseFromAccountFlag(fromAccount []string, configs []config.SupplierConfig) error {
+	for _, flag := range fromAccount {
+		i := strings.LastIndex(flag, "=")
+		if i <= 0 || i == len(flag)-1 {
+			return fmt.Errorf("Unable to parse from-account flag: %s\nMust be of kind: FROM=ACCOUNT_ID", flag)
+		}
+		from, account := flag[:i], flag[i+1:]
+
+		found := false
+		for j := range configs {
+			if configs[j].String() == from {
+				configs[j].Account = account
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("Unable to find IaC source %s of from-account flag in from flag", from)
+		}
+	}
+	return nil
+}
+
 func parseOutputFlag(out string) (*output.OutputConfig, error) {
 	schemeOpts := strings.Split(out, "://")
 	if len(schemeOpts) < 2 || schemeOpts[0] == "" {
@@ -2,8 +2,11 @@ package output
 
 import (
 	"fmt"
+	"os"
 	"reflect"
+	"sort"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/cloudskiff/driftctl/pkg/analyser"
 	"github.com/cloudskiff/driftctl/pkg/resource"
@@ -39,7 +42,7 @@ func (c *Console) Write(analysis *analyser.Analysis) error {
 				if ok {
 					fmt.Printf(" (%s)", stringer.String())
 				}
-				fmt.Println()
+				fmt.Printf("%s\n", location(res))
 			}
 		}
 	}
@@ -55,7 +58,7 @@ func (c *Console) Write(analysis *analyser.Analysis) error {
 				if ok {
 					fmt.Printf(" (%s)", stringer.String())
 				}
-				fmt.Println()
+				fmt.Printf("%s\n", location(res))
 			}
 		}
 	}
@@ -68,7 +71,7 @@ func (c *Console) Write(analysis *analyser.Analysis) error {
 			if ok {
 				humanString = stringer.String()
 			}
-			fmt.Printf("  - %s (%s):\n", difference.Res.TerraformId(), humanString)
+			fmt.Printf("  - %s (%s)%s:\n", difference.Res.TerraformId(), humanString, location(difference.Res))
 			for _, change := range difference.Changelog {
 				path := strings.Join(change.Path, ".")
 				pref := fmt.Sprintf("%s %s:", color.YellowString("~"), path)
@@ -112,17 +115,28 @@ func (c Console) writeSummary(analysis *analyser.Analysis) {
 	errorWriter := color.New(color.Bold, color.FgRed)
 	total := boldWriter.Sprintf("%d", analysis.Summary().TotalResources)
 
+	if sources := analysis.IacSources(); len(sources) > 0 {
+		fmt.Printf("Read IaC from %s source(s):\n", boldWriter.Sprintf("%d", len(sources)))
+		for _, source := range sources {
+			fmt.Printf(" - %s\n", source)
+		}
+	}
+
 	fmt.Printf(
 		"Found %s resource(s)\n",
 		total,
 	)
-	fmt.Printf(
-		" - %s%% coverage\n",
-		boldWriter.Sprintf(
-			"%d",
-			analysis.Coverage(),
-		),
-	)
+	// Coverage is unknown when unmanaged resources are not reported, and drift when resources are not compared
+	options := analysis.Options()
+	if !options.SkipUnmanaged {
+		fmt.Printf(
+			" - %s%% coverage\n",
+			boldWriter.Sprintf(
+				"%g",
+				analysis.Coverage(),
+			),
+		)
+	}
 	if !analysis.IsSync() {
 		managed := successWriter.Sprintf("0")
 		if analysis.Summary().TotalManaged > 0 {
@@ -134,7 +148,9 @@ func (c Console) writeSummary(analysis *analyser.Analysis) {
 		if analysis.Summary().TotalUnmanaged > 0 {
 			unmanaged = warningWriter.Sprintf("%d", analysis.Summary().TotalUnmanaged)
 		}
-		fmt.Printf(" - %s not covered by IaC\n", unmanaged)
+		if !options.SkipUnmanaged {
+			fmt.Printf(" - %s not covered by IaC\n", unmanaged)
+		}
 
 		deleted := successWriter.Sprintf("0")
 		if analysis.Summary().TotalDeleted > 0 {
@@ -146,11 +162,80 @@ func (c Console) writeSummary(analysis *analyser.Analysis) {
 		if analysis.Summary().TotalDrifted > 0 {
 			drifted = errorWriter.Sprintf("%d", analysis.Summary().TotalDrifted)
 		}
-		fmt.Printf(" - %s drifted from IaC\n", boldWriter.Sprintf("%s/%d", drifted, analysis.Summary().TotalManaged))
+		if !options.SkipDiffs {
+			fmt.Printf(" - %s drifted from IaC\n", boldWriter.Sprintf("%s/%d", drifted, analysis.Summary().TotalManaged))
+		}
 	}
-	if analysis.IsSync() {
+	if analysis.IsSync() && options.SkipDiffs {
+		fmt.Println(color.GreenString("Congrats! Your infrastructure is fully covered by IaC."))
+	} else if analysis.IsSync() {
 		fmt.Println(color.GreenString("Congrats! Your infrastructure is fully in sync."))
 	}
+
+	// Breakdowns show which types, regions or states lag behind, they are useless when everything is covered
+	if !options.SkipUnmanaged && analysis.Summary().TotalManaged < analysis.Summary().TotalResources {
+		writeCoverage("resource type", analysis.CoverageByType(), 1)
+		writeCoverage("region", analysis.CoverageByRegion(), 2)
+		writeCoverage("IaC source", analysis.CoverageByState(), 2)
+	}
+}
+
+// writeCoverage writes a table of the coverage of each group, least covered first,
+// it is not written when there are fewer groups than min
+func writeCoverage(group string, details map[string]analyser.CoverageDetail, min int) {
+	if len(details) < min {
+		return
+	}
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if details[keys[i]].Coverage != details[keys[j]].Coverage {
+			return details[keys[i]].Coverage < details[keys[j]].Coverage
+		}
+		return keys[i] < keys[j]
+	})
+
+	fmt.Printf("Coverage by %s:\n", group)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  %s\tMANAGED\tUNMANAGED\tDELETED\tDRIFTED\tCOVERAGE\n", strings.ToUpper(group))
+	for _, key := range keys {
+		detail := details[key]
+		fmt.Fprintf(
+			w,
+			"  %s\t%d\t%d\t%d\t%d\t%g%%\n",
+			key,
+			detail.TotalManaged,
+			detail.TotalUnmanaged,
+			detail.TotalDeleted,
+			detail.TotalDrifted,
+			detail.Coverage,
+		)
+	}
+	_ = w.Flush()
+}
+
+// location returns where a resource has been found in IaC and on the cloud provider, when known
+func location(res resource.Resource) string {
+	meta := resource.GetMetadata(res)
+	if meta == nil {
+		return ""
+	}
+	parts := make([]string, 0, 3)
+	if meta.Source != nil {
+		parts = append(parts, meta.Source.String())
+	}
+	if meta.Account != "" {
+		parts = append(parts, fmt.Sprintf("account %s", meta.Account))
+	}
+	if meta.Region != "" {
+		parts = append(parts, fmt.Sprintf("region %s", meta.Region))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" [%s]", strings.Join(parts, ", "))
 }
 
 func prettify(resource interface{}) string {
@@ -55,6 +55,36 @@ func TestConsole_Write(t *testing.T) {
 			args:       args{analysis: fakeAnalysisWithComputedFields()},
 			wantErr:    false,
 		},
+		{
+			name:       "test console output with resources sources",
+			goldenfile: "output_sources.txt",
+			args:       args{analysis: fakeAnalysisWithSources()},
+			wantErr:    false,
+		},
+		{
+			name:       "test console output with expanded IaC sources",
+			goldenfile: "output_iac_sources.txt",
+			args:       args{analysis: fakeAnalysisWithIacSources()},
+			wantErr:    false,
+		},
+		{
+			name:       "test console output in coverage mode",
+			goldenfile: "output_coverage_only.txt",
+			args:       args{analysis: fakeAnalysisCoverageOnly()},
+			wantErr:    false,
+		},
+		{
+			name:       "test console output in coverage mode without drift",
+			goldenfile: "output_coverage_only_no_drift.txt",
+			args:       args{analysis: fakeAnalysisCoverageOnlyNoDrift()},
+			wantErr:    false,
+		},
+		{
+			name:       "test console output in detect mode",
+			goldenfile: "output_detect_only.txt",
+			args:       args{analysis: fakeAnalysisDetectOnly()},
+			wantErr:    false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,312 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	resourceaws "github.com/cloudskiff/driftctl/pkg/resource/aws"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// Types the Terraform AWS provider cannot import
+var notImportableTypes = map[string]bool{
+	resourceaws.AwsIamAccessKeyResourceType:        true,
+	resourceaws.AwsIamPolicyAttachmentResourceType: true,
+}
+
+// defaultRegionSupplier is implemented by providers configured with a default region, resources of other regions
+// are imported with a provider alias named after their region
+type defaultRegionSupplier interface {
+	DefaultRegion() string
+}
+
+// Imports writes a Terraform configuration with an import block and a resource skeleton for every unmanaged resource
+type Imports struct {
+	path    string
+	schemas terraform.SchemaSupplier
+}
+
+func NewImports(path string, schemas terraform.SchemaSupplier) *Imports {
+	return &Imports{path, schemas}
+}
+
+func (c *Imports) Write(analysis *analyser.Analysis) error {
+	file, err := os.OpenFile(c.path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(c.generate(analysis.Unmanaged())); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Imports) generate(resources []resource.Resource) []byte {
+	resources = append([]resource.Resource{}, resources...)
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].TerraformType() != resources[j].TerraformType() {
+			return resources[i].TerraformType() < resources[j].TerraformType()
+		}
+		return resources[i].TerraformId() < resources[j].TerraformId()
+	})
+
+	defaultRegion := ""
+	if supplier, ok := c.schemas.(defaultRegionSupplier); ok {
+		defaultRegion = supplier.DefaultRegion()
+	}
+
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	names := make(map[string]bool)
+	for _, res := range resources {
+		ty := res.TerraformType()
+		if notImportableTypes[ty] {
+			logrus.WithFields(logrus.Fields{
+				"id":   res.TerraformId(),
+				"type": ty,
+			}).Warn("Resource type cannot be imported, it is not written")
+			continue
+		}
+		if len(body.Blocks()) > 0 {
+			body.AppendNewline()
+		}
+		name := resourceName(res.TerraformId(), ty, names)
+
+		var providerAlias hcl.Traversal
+		if meta := resource.GetMetadata(res); meta != nil && meta.Region != "" && meta.Region != defaultRegion {
+			providerAlias = hcl.Traversal{
+				hcl.TraverseRoot{Name: terraform.AWS},
+				hcl.TraverseAttr{Name: meta.Region},
+			}
+		}
+
+		importBlock := body.AppendNewBlock("import", nil)
+		importBody := importBlock.Body()
+		importBody.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: ty},
+			hcl.TraverseAttr{Name: name},
+		})
+		importBody.SetAttributeValue("id", cty.StringVal(importId(res)))
+
+		// Without a skeleton, terraform plan -generate-config-out can still write the configuration of the import block
+		schema, exists := c.schemas.Schema()[ty]
+		if !exists || schema.Block == nil {
+			logrus.WithFields(logrus.Fields{
+				"id":   res.TerraformId(),
+				"type": ty,
+			}).Warn("Unable to find the schema of the resource, only its import block is written")
+			if providerAlias != nil {
+				importBody.SetAttributeTraversal("provider", providerAlias)
+			}
+			continue
+		}
+		val, err := gocty.ToCtyValue(res, resource.ConformValue(cty.NullVal(schema.Block.ImpliedType()), res).Type())
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"id":   res.TerraformId(),
+				"type": ty,
+				"err":  err.Error(),
+			}).Warn("Unable to convert the resource to its schema, only its import block is written")
+			if providerAlias != nil {
+				importBody.SetAttributeTraversal("provider", providerAlias)
+			}
+			continue
+		}
+
+		body.AppendNewline()
+		resourceBody := body.AppendNewBlock("resource", []string{ty, name}).Body()
+		if providerAlias != nil {
+			resourceBody.SetAttributeTraversal("provider", providerAlias)
+		}
+		writeSkeleton(resourceBody, schema.Block, val)
+	}
+
+	return f.Bytes()
+}
+
+// importId returns the ID the Terraform AWS provider expects to import a resource, most types are imported with their
+// ID but a few of them with a combination of their attributes
+func importId(res resource.Resource) string {
+	switch r := res.(type) {
+	case *resourceaws.AwsRoute:
+		destination := firstNonEmpty(r.DestinationCidrBlock, r.DestinationIpv6CidrBlock, r.DestinationPrefixListId)
+		if r.RouteTableId != nil && destination != "" {
+			return fmt.Sprintf("%s_%s", *r.RouteTableId, destination)
+		}
+	case *resourceaws.AwsRouteTableAssociation:
+		target := firstNonEmpty(r.SubnetId, r.GatewayId)
+		if r.RouteTableId != nil && target != "" {
+			return fmt.Sprintf("%s/%s", target, *r.RouteTableId)
+		}
+	case *resourceaws.AwsSecurityGroupRule:
+		if id, ok := securityGroupRuleImportId(r); ok {
+			return id
+		}
+	case *resourceaws.AwsIamUserPolicyAttachment:
+		if r.User != nil && r.PolicyArn != nil {
+			return fmt.Sprintf("%s/%s", *r.User, *r.PolicyArn)
+		}
+	case *resourceaws.AwsIamRolePolicyAttachment:
+		if r.Role != nil && r.PolicyArn != nil {
+			return fmt.Sprintf("%s/%s", *r.Role, *r.PolicyArn)
+		}
+	default:
+		return res.TerraformId()
+	}
+	logrus.WithFields(logrus.Fields{
+		"id":   res.TerraformId(),
+		"type": res.TerraformType(),
+	}).Warn("Unable to build the import ID of the resource, its ID is written instead")
+	return res.TerraformId()
+}
+
+// securityGroupRuleImportId returns SECURITYGROUPID_TYPE_PROTOCOL_FROMPORT_TOPORT_SOURCE[_SOURCE]*
+func securityGroupRuleImportId(r *resourceaws.AwsSecurityGroupRule) (string, bool) {
+	if r.SecurityGroupId == nil || r.Type == nil || r.Protocol == nil || r.FromPort == nil || r.ToPort == nil {
+		return "", false
+	}
+	var sources []string
+	for _, blocks := range []*[]string{r.CidrBlocks, r.Ipv6CidrBlocks, r.PrefixListIds} {
+		if blocks != nil {
+			sources = append(sources, *blocks...)
+		}
+	}
+	if r.Self != nil && *r.Self {
+		sources = append(sources, "self")
+	}
+	if r.SourceSecurityGroupId != nil && *r.SourceSecurityGroupId != "" {
+		sources = append(sources, *r.SourceSecurityGroupId)
+	}
+	if len(sources) == 0 {
+		return "", false
+	}
+	return fmt.Sprintf(
+		"%s_%s_%s_%d_%d_%s",
+		*r.SecurityGroupId,
+		*r.Type,
+		*r.Protocol,
+		*r.FromPort,
+		*r.ToPort,
+		strings.Join(sources, "_"),
+	), true
+}
+
+func firstNonEmpty(values ...*string) string {
+	for _, value := range values {
+		if value != nil && *value != "" {
+			return *value
+		}
+	}
+	return ""
+}
+
+// resourceName turns an ID into a valid Terraform name, unique among resources of the same type
+func resourceName(id, ty string, names map[string]bool) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(id, "_"), "_")
+	if name == "" || !(name[0] == '_' || (name[0] >= 'a' && name[0] <= 'z') || (name[0] >= 'A' && name[0] <= 'Z')) {
+		name = "r_" + name
+	}
+	unique := name
+	for i := 2; names[ty+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	names[ty+"."+unique] = true
+	return unique
+}
+
+// writeSkeleton writes attributes read on the cloud provider that can be set in a configuration, computed-only and
+// sensitive attributes are left out
+func writeSkeleton(body *hclwrite.Body, block *configschema.Block, val cty.Value) {
+	if val.IsNull() || !val.IsKnown() {
+		return
+	}
+
+	attrNames := make([]string, 0, len(block.Attributes))
+	for name := range block.Attributes {
+		attrNames = append(attrNames, name)
+	}
+	sort.Strings(attrNames)
+	for _, name := range attrNames {
+		attr := block.Attributes[name]
+		// The ID is given by the import block
+		if name == "id" || (attr.Computed && !attr.Optional && !attr.Required) || attr.Sensitive {
+			continue
+		}
+		if !val.Type().HasAttribute(name) {
+			continue
+		}
+		attrVal := val.GetAttr(name)
+		if isEmptyValue(attrVal) && !attr.Required {
+			continue
+		}
+		body.SetAttributeValue(name, attrVal)
+	}
+
+	blockNames := make([]string, 0, len(block.BlockTypes))
+	for name := range block.BlockTypes {
+		blockNames = append(blockNames, name)
+	}
+	sort.Strings(blockNames)
+	for _, name := range blockNames {
+		nested := block.BlockTypes[name]
+		if !val.Type().HasAttribute(name) {
+			continue
+		}
+		blockVal := val.GetAttr(name)
+		if blockVal.IsNull() || !blockVal.IsKnown() {
+			continue
+		}
+		switch nested.Nesting {
+		case configschema.NestingSingle, configschema.NestingGroup:
+			writeNestedBlock(body, name, nil, &nested.Block, blockVal)
+		case configschema.NestingList, configschema.NestingSet:
+			for it := blockVal.ElementIterator(); it.Next(); {
+				_, elem := it.Element()
+				writeNestedBlock(body, name, nil, &nested.Block, elem)
+			}
+		case configschema.NestingMap:
+			for it := blockVal.ElementIterator(); it.Next(); {
+				key, elem := it.Element()
+				writeNestedBlock(body, name, []string{key.AsString()}, &nested.Block, elem)
+			}
+		}
+	}
+}
+
+func writeNestedBlock(body *hclwrite.Body, name string, labels []string, block *configschema.Block, val cty.Value) {
+	nested := body.AppendNewBlock(name, labels)
+	writeSkeleton(nested.Body(), block, val)
+	// Blocks holding only computed attributes would be written empty
+	if len(nested.Body().Attributes()) == 0 && len(nested.Body().Blocks()) == 0 {
+		body.RemoveBlock(nested)
+	}
+}
+
+func isEmptyValue(val cty.Value) bool {
+	if val.IsNull() || !val.IsKnown() {
+		return true
+	}
+	ty := val.Type()
+	if ty == cty.String {
+		return val.AsString() == ""
+	}
+	if ty.IsListType() || ty.IsSetType() || ty.IsMapType() || ty.IsTupleType() {
+		return val.LengthInt() == 0
+	}
+	return false
+}
@@ -38,6 +38,22 @@ func TestJSON_Write(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:       "test json output with resources sources",
+			goldenfile: "output_sources.json",
+			args: args{
+				analysis: fakeAnalysisWithSources(),
+			},
+			wantErr: false,
+		},
+		{
+			name:       "test json output with expanded IaC sources",
+			goldenfile: "output_iac_sources.json",
+			args: args{
+				analysis: fakeAnalysisWithIacSources(),
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
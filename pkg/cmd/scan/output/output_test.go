@@ -5,6 +5,7 @@ import (
 
 	"github.com/cloudskiff/driftctl/pkg/alerter"
 	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
 	testresource "github.com/cloudskiff/driftctl/test/resource"
 	"github.com/r3labs/diff/v2"
 )
@@ -237,3 +238,90 @@ func fakeAnalysisWithComputedFields() *analyser.Analysis {
 	})
 	return &a
 }
+
+func fakeAnalysisWithSources() *analyser.Analysis {
+	a := analyser.Analysis{}
+	a.AddDeleted(
+		&testresource.FakeResource{
+			Metadata: resource.Metadata{
+				Source: &resource.Source{
+					State:   "tfstate://terraform.tfstate",
+					Address: "aws_deleted_resource.deleted",
+				},
+			},
+			Id:   "deleted-id-1",
+			Type: "aws_deleted_resource",
+		},
+	)
+	a.AddUnmanaged(
+		&testresource.FakeResource{
+			Metadata: resource.Metadata{
+				Region:  "us-east-1",
+				Account: "123456789012",
+			},
+			Id:   "unmanaged-id-1",
+			Type: "aws_unmanaged_resource",
+		},
+	)
+	diffResource := &testresource.FakeResource{
+		Metadata: resource.Metadata{
+			Source: &resource.Source{
+				State:     "tfstate+s3://bucket/env:/staging/terraform.tfstate",
+				Address:   `module.logs.aws_diff_resource.diff["eu"]`,
+				Module:    "module.logs",
+				Workspace: "staging",
+			},
+			Region: "eu-west-1",
+		},
+		Id:   "diff-id-1",
+		Type: "aws_diff_resource",
+	}
+	a.AddManaged(diffResource)
+	a.AddDifference(analyser.Difference{Res: diffResource, Changelog: []analyser.Change{
+		{
+			Change: diff.Change{
+				Type: diff.UPDATE,
+				Path: []string{"updated", "field"},
+				From: "foobar",
+				To:   "barfoo",
+			},
+		},
+	}})
+	return &a
+}
+
+func fakeAnalysisCoverageOnly() *analyser.Analysis {
+	a := analyser.Analysis{}
+	a.SetOptions(analyser.AnalyzerOptions{SkipDiffs: true})
+	fake := fakeAnalysis()
+	a.AddManaged(fake.Managed()...)
+	a.AddUnmanaged(fake.Unmanaged()...)
+	a.AddDeleted(fake.Deleted()...)
+	return &a
+}
+
+func fakeAnalysisCoverageOnlyNoDrift() *analyser.Analysis {
+	a := fakeAnalysisNoDrift()
+	a.SetOptions(analyser.AnalyzerOptions{SkipDiffs: true})
+	return a
+}
+
+func fakeAnalysisDetectOnly() *analyser.Analysis {
+	a := analyser.Analysis{}
+	a.SetOptions(analyser.AnalyzerOptions{SkipUnmanaged: true})
+	fake := fakeAnalysis()
+	a.AddManaged(fake.Managed()...)
+	a.AddDeleted(fake.Deleted()...)
+	a.AddDifference(fake.Differences()...)
+	return &a
+}
+
+func fakeAnalysisWithIacSources() *analyser.Analysis {
+	a := fakeAnalysisNoDrift()
+	a.SetIacSources([]string{
+		"tfstate://envs/prod/terraform.tfstate",
+		"tfstate://envs/staging/terraform.tfstate",
+		"tfstate+s3://bucket/states/network.tfstate",
+	})
+	return a
+}
@@ -0,0 +1,51 @@
+import {
+  to = aws_iam_user.test-user
+  id = "test-user"
+}
+
+resource "aws_iam_user" "test-user" {
+  force_destroy = false
+  name          = "test-user"
+  path          = "/"
+  tags = {
+    Team = "platform"
+  }
+}
+
+import {
+  to = aws_iam_user.test_user
+  id = "test.user"
+}
+
+resource "aws_iam_user" "test_user" {
+  name = "test.user"
+}
+
+import {
+  to = aws_iam_user.test_user_2
+  id = "test_user"
+}
+
+resource "aws_iam_user" "test_user_2" {
+  name = "test_user"
+}
+
+import {
+  to = aws_instance.i-0123456789
+  id = "i-0123456789"
+}
+
+resource "aws_instance" "i-0123456789" {
+  ami = "ami-0123456789"
+  ebs_block_device {
+    device_name = "/dev/sdb"
+  }
+  ebs_block_device {
+    device_name = "/dev/sdc"
+  }
+}
+
+import {
+  to = aws_unknown_resource.r_1234
+  id = "1234"
+}
@@ -0,0 +1,69 @@
+import {
+  to = aws_iam_role_policy_attachment.test-role-20210101000000000000000002
+  id = "test-role/arn:aws:iam::aws:policy/ReadOnlyAccess"
+}
+
+import {
+  to = aws_iam_user_policy_attachment.test-user-20210101000000000000000001
+  id = "test-user/arn:aws:iam::123456789012:policy/test-policy"
+}
+
+import {
+  to = aws_instance.i-0123456789
+  id = "i-0123456789"
+}
+
+resource "aws_instance" "i-0123456789" {
+  provider = aws.us-east-1
+  ami      = "ami-0123456789"
+}
+
+import {
+  to = aws_instance.i-4567
+  id = "i-4567"
+}
+
+resource "aws_instance" "i-4567" {
+  ami = "ami-4567"
+}
+
+import {
+  to = aws_route.r-rtb-0123456781080289494
+  id = "rtb-0123_0.0.0.0/0"
+}
+
+import {
+  to       = aws_route.r-rtb-45672750132273
+  id       = "rtb-4567_::/0"
+  provider = aws.us-east-1
+}
+
+import {
+  to = aws_route.r-rtb-891011
+  id = "r-rtb-891011"
+}
+
+import {
+  to = aws_route_table_association.rtbassoc-0123
+  id = "subnet-0123/rtb-0123"
+}
+
+import {
+  to = aws_route_table_association.rtbassoc-4567
+  id = "igw-4567/rtb-4567"
+}
+
+import {
+  to = aws_security_group_rule.sgrule-0123
+  id = "sg-0123_ingress_tcp_80_443_10.0.0.0/8_192.168.0.0/16_::/0"
+}
+
+import {
+  to = aws_security_group_rule.sgrule-4567
+  id = "sg-0123_egress_-1_0_0_sg-4567"
+}
+
+import {
+  to = aws_security_group_rule.sgrule-891011
+  id = "sg-0123_ingress_tcp_22_22_self"
+}
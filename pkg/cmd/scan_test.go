@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/cloudskiff/driftctl/pkg/cmd/scan/output"
@@ -39,6 +41,32 @@ func TestScanCmd_Valid(t *testing.T) {
 		{args: []string{"scan", "-t", "aws+tf", "-f", "tfstate://test"}},
 		{args: []string{"scan", "--to", "aws+tf", "--from", "tfstate://test"}},
 		{args: []string{"scan", "--filter", "Type=='aws_s3_bucket'"}},
+		{args: []string{"scan", "--from", "tfstate+s3://bucket/state.tfstate", "--tf-workspace", "staging"}},
+		{args: []string{"scan", "--regions", "eu-west-1,us-east-1"}},
+		{args: []string{"scan", "--regions", "all"}},
+		{args: []string{"scan", "--assume-roles", "arn:aws:iam::111111111111:role/audit,arn:aws:iam::222222222222:role/audit", "--assume-role-external-id", "driftctl"}},
+		{args: []string{"scan", "--from", "tfstate://prod.tfstate", "--from-account", "tfstate://prod.tfstate=111111111111"}},
+		{args: []string{"scan", "--aws-profile", "audit", "--aws-max-retries", "3"}},
+		{args: []string{"scan", "--assume-roles", "arn:aws:iam::111111111111:role/audit", "--assume-role-session-name", "audit"}},
+		{args: []string{"scan", "--assume-roles", "arn:aws:iam::111111111111:role/audit", "--assume-role-read-only"}},
+		{args: []string{"scan", "--allowed-account-ids", "111111111111,222222222222"}},
+		{args: []string{"scan", "--provider-path", "/opt/terraform-provider-aws_v3.19.0_x5"}},
+		{args: []string{"scan", "--plugin-dir", "/opt/terraform/plugins"}},
+		{args: []string{"scan", "--plugin-dir", "/opt/terraform/plugins", "--allow-unverified-provider"}},
+		{args: []string{"scan", "--tf-lockfile", "../terraform/testdata/lockfile/.terraform.lock.hcl"}},
+		{args: []string{"scan", "--provider-version", "3.30.0"}},
+		{args: []string{"scan", "--provider-mirror-url", "https://artifactory.example.com/hashicorp"}},
+		{args: []string{"scan", "--aws-endpoints", "ec2=http://localhost:4566,s3=http://localhost:4566", "--aws-s3-force-path-style"}},
+		{args: []string{"scan", "--from", "tfstate+s3://bucket/state.tfstate", "--tf-workspace", "*", "--tf-workspace-key-prefix", "workspaces"}},
+		{args: []string{"scan", "--include-types", "aws_s3_*,aws_iam_user", "--exclude-types", "aws_s3_bucket_policy"}},
+		{args: []string{"scan", "--parallelism", "4", "--aws-read-parallelism", "20", "--iac-parallelism", "2"}},
+		{args: []string{"scan", "--cache-ttl", "2h", "--cache-dir", "/tmp/driftctl", "--refresh-cache"}},
+		{args: []string{"scan", "--fail-on", "deleted,drifted", "--fail-threshold", "5"}},
+		{args: []string{"scan", "--fail-on", "", "--min-coverage", "80"}},
+		{args: []string{"scan", "--coverage-only"}},
+		{args: []string{"scan", "--coverage-only=false", "--detect-only", "--min-coverage", "0"}},
+		{args: []string{"scan", "--detect-only=false", "--generate-imports", "imports.tf"}},
+		{args: []string{"scan", "--coverage-only=false", "--generate-imports", "imports.tf"}},
 	}
 
 	for _, tt := range cases {
@@ -67,15 +95,35 @@ func TestScanCmd_Invalid(t *testing.T) {
 		{args: []string{"scan", "-f"}, expected: `flag needs an argument: 'f' in -f`},
 		{args: []string{"scan", "--from"}, expected: `flag needs an argument: --from`},
 		{args: []string{"scan", "--from"}, expected: `flag needs an argument: --from`},
-		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs"}, expected: "Unable to parse from flag: tosdgjhgsdhgkjs\nAccepted schemes are: tfstate://,tfstate+s3://"},
-		{args: []string{"scan", "--from", "://"}, expected: "Unable to parse from flag: ://\nAccepted schemes are: tfstate://,tfstate+s3://"},
-		{args: []string{"scan", "--from", "://test"}, expected: "Unable to parse from flag: ://test\nAccepted schemes are: tfstate://,tfstate+s3://"},
-		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs://"}, expected: "Unable to parse from flag: tosdgjhgsdhgkjs://\nAccepted schemes are: tfstate://,tfstate+s3://"},
-		{args: []string{"scan", "--from", "terraform+foo+bar://test"}, expected: "Unable to parse from scheme: terraform+foo+bar\nAccepted schemes are: tfstate://,tfstate+s3://"},
-		{args: []string{"scan", "--from", "unsupported://test"}, expected: "Unsupported IaC source: unsupported\nAccepted values are: tfstate"},
-		{args: []string{"scan", "--from", "tfstate+foobar://test"}, expected: "Unsupported IaC backend: foobar\nAccepted values are: s3"},
-		{args: []string{"scan", "--from", "tfstate:///tmp/test", "--from", "tfstate+toto://test"}, expected: "Unsupported IaC backend: toto\nAccepted values are: s3"},
+		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs"}, expected: "Unable to parse from flag: tosdgjhgsdhgkjs\nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfplan://,tfplan+s3://,tfplan+http://,tfplan+https://,tfplan+tfcloud://"},
+		{args: []string{"scan", "--from", "://"}, expected: "Unable to parse from flag: ://\nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfplan://,tfplan+s3://,tfplan+http://,tfplan+https://,tfplan+tfcloud://"},
+		{args: []string{"scan", "--from", "://test"}, expected: "Unable to parse from flag: ://test\nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfplan://,tfplan+s3://,tfplan+http://,tfplan+https://,tfplan+tfcloud://"},
+		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs://"}, expected: "Unable to parse from flag: tosdgjhgsdhgkjs://\nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfplan://,tfplan+s3://,tfplan+http://,tfplan+https://,tfplan+tfcloud://"},
+		{args: []string{"scan", "--from", "terraform+foo+bar://test"}, expected: "Unable to parse from scheme: terraform+foo+bar\nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfplan://,tfplan+s3://,tfplan+http://,tfplan+https://,tfplan+tfcloud://"},
+		{args: []string{"scan", "--from", "unsupported://test"}, expected: "Unsupported IaC source: unsupported\nAccepted values are: tfstate,tfplan"},
+		{args: []string{"scan", "--from", "tfstate+foobar://test"}, expected: "Unsupported IaC backend: foobar\nAccepted values are: s3,http,https,tfcloud"},
+		{args: []string{"scan", "--from", "tfstate:///tmp/test", "--from", "tfstate+toto://test"}, expected: "Unsupported IaC backend: toto\nAccepted values are: s3,http,https,tfcloud"},
 		{args: []string{"scan", "--filter", "Type='test'"}, expected: "unable to parse filter expression: SyntaxError: Expected tRbracket, received: tUnknown"},
+		{args: []string{"scan", "--allowed-account-ids", "111111111111", "--forbidden-account-ids", "222222222222"}, expected: "--allowed-account-ids and --forbidden-account-ids cannot be used together"},
+		{args: []string{"scan", "--aws-endpoints", "http://localhost:4566"}, expected: `invalid argument "http://localhost:4566" for "--aws-endpoints" flag: http://localhost:4566 must be formatted as key=value`},
+		{args: []string{"scan", "--aws-max-retries", "ten"}, expected: `invalid argument "ten" for "--aws-max-retries" flag: strconv.ParseInt: parsing "ten": invalid syntax`},
+		{args: []string{"scan", "--tf-lockfile", "missing.terraform.lock.hcl"}, expected: "unable to read lock file missing.terraform.lock.hcl: stat missing.terraform.lock.hcl: no such file or directory"},
+		{args: []string{"scan", "--from-account", "111111111111"}, expected: "Unable to parse from-account flag: 111111111111\nMust be of kind: FROM=ACCOUNT_ID"},
+		{args: []string{"scan", "--from-account", "tfstate://prod.tfstate="}, expected: "Unable to parse from-account flag: tfstate://prod.tfstate=\nMust be of kind: FROM=ACCOUNT_ID"},
+		{args: []string{"scan", "--from-account", "tfstate://prod.tfstate=111111111111"}, expected: "Unable to find IaC source tfstate://prod.tfstate of from-account flag in from flag"},
+		{args: []string{"scan", "--refresh-cache"}, expected: "--refresh-cache requires the cache to be enabled with --cache-ttl"},
+		{args: []string{"scan", "--cache-ttl", "2 hours"}, expected: `invalid argument "2 hours" for "--cache-ttl" flag: time: unknown unit " hours" in duration "2 hours"`},
+		{args: []string{"scan", "--parallelism", "0"}, expected: "--parallelism and --aws-read-parallelism must be at least 1"},
+		{args: []string{"scan", "--aws-read-parallelism", "-1"}, expected: "--parallelism and --aws-read-parallelism must be at least 1"},
+		{args: []string{"scan", "--fail-on", "changed"}, expected: "unsupported drift category 'changed' in --fail-on\nValid values are: deleted,drifted,unmanaged"},
+		{args: []string{"scan", "--fail-threshold", "-1"}, expected: "--fail-threshold cannot be negative"},
+		{args: []string{"scan", "--min-coverage", "101"}, expected: "--min-coverage must be a percentage between 0 and 100"},
+		{args: []string{"scan", "--coverage-only", "--detect-only"}, expected: "--coverage-only and --detect-only cannot be used together"},
+		{args: []string{"scan", "--detect-only", "--min-coverage", "50"}, expected: "--min-coverage cannot be used with --detect-only as unmanaged resources are not reported"},
+		{args: []string{"scan", "--detect-only", "--generate-imports", "imports.tf"}, expected: "--generate-imports cannot be used with --detect-only as unmanaged resources are not reported"},
+		{args: []string{"scan", "--coverage-only", "--generate-imports", "imports.tf"}, expected: "--generate-imports cannot be used with --coverage-only as resources are not read"},
+		{args: []string{"scan", "--include-types", "aws_s3_bucket,aws_foobar"}, expected: "Unsupported resource type(s) in --include-types or --exclude-types: aws_foobar"},
+		{args: []string{"scan", "--exclude-types", "aws_s3_[bucket"}, expected: "invalid resource type pattern aws_s3_[bucket: syntax error in pattern"},
 	}
 
 	for _, tt := range cases {
@@ -236,3 +284,32 @@ func Test_parseOutputFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestScanCmd_ExitErrorWithoutUsage(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "root"}
+	scanCmd := NewScanCmd()
+	scanCmd.RunE = func(_ *cobra.Command, args []string) error {
+		return ExitError{Code: ExitCodeDriftFound, Err: errors.New("1 resource(s) unmanaged, 0 tolerated")}
+	}
+	rootCmd.AddCommand(scanCmd)
+
+	output, err := test.Execute(rootCmd, "scan")
+	var exitErr ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("Expected an exit error, got %v", err)
+	}
+	if exitErr.Code != ExitCodeDriftFound {
+		t.Errorf("Expected exit code %d, got %d", ExitCodeDriftFound, exitErr.Code)
+	}
+	if strings.Contains(output, "Usage:") {
+		t.Errorf("Usage should not be printed on drift, got %v", output)
+	}
+
+	// Usage is still printed when flags are invalid
+	rootCmd = &cobra.Command{Use: "root"}
+	rootCmd.AddCommand(NewScanCmd())
+	output, _ = test.Execute(rootCmd, "scan", "--fail-threshold", "-1")
+	if !strings.Contains(output, "Usage:") {
+		t.Errorf("Usage should be printed on invalid flags, got %v", output)
+	}
+}
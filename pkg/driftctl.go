@@ -17,10 +17,11 @@ type DriftCTL struct {
 	iacSupplier    resource.Supplier
 	analyzer       analyser.Analyzer
 	filter         *jmespath.JMESPath
+	typeFilter     *filter.TypeFilter
 }
 
-func NewDriftCTL(remoteSupplier resource.Supplier, iacSupplier resource.Supplier, filter *jmespath.JMESPath, alerter *alerter.Alerter) *DriftCTL {
-	return &DriftCTL{remoteSupplier, iacSupplier, analyser.NewAnalyzer(alerter), filter}
+func NewDriftCTL(remoteSupplier resource.Supplier, iacSupplier resource.Supplier, filter *jmespath.JMESPath, typeFilter *filter.TypeFilter, alerter *alerter.Alerter, options analyser.AnalyzerOptions) *DriftCTL {
+	return &DriftCTL{remoteSupplier, iacSupplier, analyser.NewAnalyzer(alerter, options), filter, typeFilter}
 }
 
 func (d DriftCTL) Run() *analyser.Analysis {
@@ -55,6 +56,10 @@ func (d DriftCTL) Run() *analyser.Analysis {
 		return nil
 	}
 
+	// Unselected types are not scanned on the cloud provider, they are left out of IaC as well
+	remoteResources = d.typeFilter.Run(remoteResources)
+	resourcesFromState = d.typeFilter.Run(resourcesFromState)
+
 	if d.filter != nil {
 		engine := filter.NewFilterEngine(d.filter)
 		remoteResources, err = engine.Run(remoteResources)
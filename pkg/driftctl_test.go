@@ -0,0 +1,81 @@
+package pkg
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/cloudskiff/driftctl/mocks"
+	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	resourceaws "github.com/cloudskiff/driftctl/pkg/resource/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+// Resources as read by the terraform provider in a full scan
+func fullRemoteResources() []resource.Resource {
+	return []resource.Resource{
+		&resourceaws.AwsDefaultVpc{Id: "vpc-default", Tags: map[string]string{"Name": "default"}},
+		&resourceaws.AwsInternetGateway{Id: "igw-default", VpcId: aws.String("vpc-default"), OwnerId: aws.String("123456789012")},
+		&resourceaws.AwsInternetGateway{Id: "igw-other", VpcId: aws.String("vpc-other"), OwnerId: aws.String("123456789012")},
+		&resourceaws.AwsRoute{Id: "r-rtb-default1080289494", RouteTableId: aws.String("rtb-default"), DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-default"), Origin: aws.String("CreateRoute"), State: aws.String("active")},
+		&resourceaws.AwsRoute{Id: "r-rtb-default179966490", RouteTableId: aws.String("rtb-default"), DestinationCidrBlock: aws.String("172.31.0.0/16"), GatewayId: aws.String("local"), Origin: aws.String("CreateRouteTable"), State: aws.String("active")},
+		&resourceaws.AwsRoute{Id: "r-rtb-other1080289494", RouteTableId: aws.String("rtb-other"), DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-other"), Origin: aws.String("CreateRoute"), State: aws.String("active")},
+		&resourceaws.AwsNatGateway{Id: "nat-1", AllocationId: aws.String("eipalloc-1"), SubnetId: aws.String("subnet-1")},
+		&resourceaws.AwsEipAssociation{Id: "eipassoc-1", AllocationId: aws.String("eipalloc-1"), NetworkInterfaceId: aws.String("eni-1")},
+		&resourceaws.AwsEipAssociation{Id: "eipassoc-2", AllocationId: aws.String("eipalloc-2"), InstanceId: aws.String("i-1"), NetworkInterfaceId: aws.String("eni-2")},
+	}
+}
+
+// Resources as only listed in coverage mode, they hold the attributes suppliers give to the reader
+func listedRemoteResources() []resource.Resource {
+	return []resource.Resource{
+		&resourceaws.AwsDefaultVpc{Id: "vpc-default"},
+		&resourceaws.AwsInternetGateway{Id: "igw-default", VpcId: aws.String("vpc-default")},
+		&resourceaws.AwsInternetGateway{Id: "igw-other", VpcId: aws.String("vpc-other")},
+		&resourceaws.AwsRoute{Id: "r-rtb-default1080289494", RouteTableId: aws.String("rtb-default"), DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-default"), Origin: aws.String("CreateRoute")},
+		&resourceaws.AwsRoute{Id: "r-rtb-default179966490", RouteTableId: aws.String("rtb-default"), DestinationCidrBlock: aws.String("172.31.0.0/16"), GatewayId: aws.String("local"), Origin: aws.String("CreateRouteTable")},
+		&resourceaws.AwsRoute{Id: "r-rtb-other1080289494", RouteTableId: aws.String("rtb-other"), DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-other"), Origin: aws.String("CreateRoute")},
+		&resourceaws.AwsNatGateway{Id: "nat-1", AllocationId: aws.String("eipalloc-1")},
+		&resourceaws.AwsEipAssociation{Id: "eipassoc-1", AllocationId: aws.String("eipalloc-1")},
+		&resourceaws.AwsEipAssociation{Id: "eipassoc-2", AllocationId: aws.String("eipalloc-2"), InstanceId: aws.String("i-1")},
+	}
+}
+
+func ids(resources []resource.Resource) []string {
+	result := make([]string, 0, len(resources))
+	for _, res := range resources {
+		result = append(result, res.TerraformType()+"."+res.TerraformId())
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestDriftCTL_CoverageOnlyMatchesFullScan(t *testing.T) {
+	run := func(remoteResources []resource.Resource, options analyser.AnalyzerOptions) *analyser.Analysis {
+		remoteSupplier := &mocks.Supplier{}
+		remoteSupplier.On("Resources").Return(remoteResources, nil)
+		iacSupplier := &mocks.Supplier{}
+		iacSupplier.On("Resources").Return([]resource.Resource{
+			&resourceaws.AwsInternetGateway{Id: "igw-other", VpcId: aws.String("vpc-other")},
+			&resourceaws.AwsNatGateway{Id: "nat-1", AllocationId: aws.String("eipalloc-1"), SubnetId: aws.String("subnet-1")},
+		}, nil)
+
+		analysis := NewDriftCTL(remoteSupplier, iacSupplier, nil, nil, alerter.NewAlerter(), options).Run()
+		if analysis == nil {
+			t.Fatal("unable to run driftctl")
+		}
+		return analysis
+	}
+
+	full := run(fullRemoteResources(), analyser.AnalyzerOptions{})
+	coverage := run(listedRemoteResources(), analyser.AnalyzerOptions{SkipDiffs: true})
+
+	assert.Equal(t, []string{"aws_internet_gateway.igw-other", "aws_nat_gateway.nat-1"}, ids(full.Managed()))
+	assert.Equal(t, []string{"aws_eip_association.eipassoc-2", "aws_route.r-rtb-other1080289494"}, ids(full.Unmanaged()))
+	assert.Equal(t, ids(full.Managed()), ids(coverage.Managed()))
+	assert.Equal(t, ids(full.Unmanaged()), ids(coverage.Unmanaged()))
+	assert.Equal(t, ids(full.Deleted()), ids(coverage.Deleted()))
+	assert.Equal(t, full.Coverage(), coverage.Coverage())
+}
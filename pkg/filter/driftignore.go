@@ -59,7 +59,8 @@ func (r *DriftIgnore) readIgnoreFile() error {
 		if !exists {
 			ignoreSublist = make([]string, 0, 1)
 		}
-		path := strings.Join(typeVal[2:], ".")
+		// Paths are kept escaped as they are split again when matched, fields may contain dots (e.g. tag keys)
+		path := DriftIgnoreLine(typeVal[2:]...)
 
 		logrus.WithFields(logrus.Fields{
 			"type": typeVal[0],
@@ -121,6 +122,17 @@ RuleCheck:
 	return false
 }
 
+// DriftIgnoreLine builds a line of .driftignore from a resource type, a resource ID and an optional field path,
+// dots and backslashes are escaped the way readDriftIgnoreLine expects them
+func DriftIgnoreLine(parts ...string) string {
+	escaped := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.ReplaceAll(part, `\`, `\\`)
+		escaped = append(escaped, strings.ReplaceAll(part, ".", `\.`))
+	}
+	return strings.Join(escaped, ".")
+}
+
 /**
  * Read a line of ignore
  * Handle split on dots and escaping
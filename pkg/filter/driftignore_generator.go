@@ -0,0 +1,85 @@
+package filter
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/sirupsen/logrus"
+)
+
+// DriftIgnoreGenerator builds the .driftignore lines ignoring the drift reported by an analysis
+type DriftIgnoreGenerator struct {
+	categories []string
+	wildcards  []string
+}
+
+// NewDriftIgnoreGenerator returns a generator of lines for the given drift categories, resources of types matching a
+// wildcard pattern (e.g. aws_s3_*) are ignored with type.* rather than one line per resource
+func NewDriftIgnoreGenerator(categories, wildcards []string) (*DriftIgnoreGenerator, error) {
+	for _, category := range categories {
+		if !analyser.IsCategory(category) {
+			return nil, fmt.Errorf("unsupported drift category %s", category)
+		}
+	}
+	for _, pattern := range wildcards {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid resource type pattern %s: %w", pattern, err)
+		}
+	}
+	return &DriftIgnoreGenerator{categories, wildcards}, nil
+}
+
+// Lines returns sorted lines without duplicates, comments and blank lines are not written as .driftignore has none
+func (g *DriftIgnoreGenerator) Lines(analysis *analyser.Analysis) []string {
+	lines := make(map[string]struct{})
+	for _, category := range g.categories {
+		switch category {
+		case analyser.CategoryUnmanaged:
+			for _, res := range analysis.Unmanaged() {
+				addDriftIgnoreLine(lines, res.TerraformType(), g.id(res))
+			}
+		case analyser.CategoryDeleted:
+			for _, res := range analysis.Deleted() {
+				addDriftIgnoreLine(lines, res.TerraformType(), g.id(res))
+			}
+		case analyser.CategoryDrifted:
+			for _, difference := range analysis.Differences() {
+				for _, change := range difference.Changelog {
+					parts := append([]string{difference.Res.TerraformType(), g.id(difference.Res)}, change.Path...)
+					addDriftIgnoreLine(lines, parts...)
+				}
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(lines))
+	for line := range lines {
+		sorted = append(sorted, line)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// addDriftIgnoreLine adds the line of the given parts unless .driftignore would read it back as other parts,
+// a few IDs and fields holding backslashes followed by dots cannot be escaped
+func addDriftIgnoreLine(lines map[string]struct{}, parts ...string) {
+	line := DriftIgnoreLine(parts...)
+	if read := readDriftIgnoreLine(line); !reflect.DeepEqual(read, parts) {
+		logrus.WithFields(logrus.Fields{
+			"line": line,
+		}).Warnf("Unable to write a .driftignore line for %v, it would be read as %v", parts, read)
+		return
+	}
+	lines[line] = struct{}{}
+}
+
+func (g *DriftIgnoreGenerator) id(res resource.Resource) string {
+	if matchAny(g.wildcards, res.TerraformType()) {
+		return "*"
+	}
+	return res.TerraformId()
+}
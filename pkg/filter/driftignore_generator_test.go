@@ -0,0 +1,99 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	resource2 "github.com/cloudskiff/driftctl/test/resource"
+	"github.com/r3labs/diff/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func fakeDriftIgnoreAnalysis() *analyser.Analysis {
+	a := &analyser.Analysis{}
+	a.AddUnmanaged(
+		&resource2.FakeResource{Type: "aws_s3_bucket", Id: "bucket.example.com"},
+		&resource2.FakeResource{Type: "aws_iam_user", Id: "user-1"},
+		&resource2.FakeResource{Type: "aws_iam_user", Id: "user-2"},
+	)
+	a.AddDeleted(
+		&resource2.FakeResource{Type: "aws_instance", Id: "i-0123456789"},
+	)
+	drifted := &resource2.FakeResource{Type: "aws_iam_role", Id: "role-1"}
+	a.AddManaged(drifted)
+	a.AddDifference(analyser.Difference{Res: drifted, Changelog: []analyser.Change{
+		{Change: diff.Change{Type: diff.UPDATE, Path: []string{"Tags", "kubernetes.io/role"}, From: "a", To: "b"}},
+		{Change: diff.Change{Type: diff.UPDATE, Path: []string{"Tags", "kubernetes.io/role"}, From: "c", To: "d"}},
+		{Change: diff.Change{Type: diff.CREATE, Path: []string{"Description"}, From: nil, To: "role"}},
+	}})
+	// Backslashes followed by dots are not read back as written, these lines are skipped
+	a.AddUnmanaged(&resource2.FakeResource{Type: "aws_iam_user", Id: `user\\`})
+	unescapable := &resource2.FakeResource{Type: "aws_iam_role", Id: "role-2"}
+	a.AddManaged(unescapable)
+	a.AddDifference(analyser.Difference{Res: unescapable, Changelog: []analyser.Change{
+		{Change: diff.Change{Type: diff.UPDATE, Path: []string{`a\.b`, "f"}, From: "a", To: "b"}},
+	}})
+	return a
+}
+
+func TestDriftIgnoreGenerator_Lines(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []string
+		wildcards  []string
+		want       []string
+	}{
+		{
+			name:       "no category",
+			categories: []string{},
+			want:       []string{},
+		},
+		{
+			name:       "every category",
+			categories: analyser.Categories(),
+			want: []string{
+				"aws_iam_role.role-1.Description",
+				`aws_iam_role.role-1.Tags.kubernetes\.io/role`,
+				"aws_iam_user.user-1",
+				"aws_iam_user.user-2",
+				"aws_instance.i-0123456789",
+				`aws_s3_bucket.bucket\.example\.com`,
+			},
+		},
+		{
+			name:       "unmanaged with wildcards",
+			categories: []string{analyser.CategoryUnmanaged},
+			wildcards:  []string{"aws_iam_*"},
+			want: []string{
+				"aws_iam_user.*",
+				`aws_s3_bucket.bucket\.example\.com`,
+			},
+		},
+		{
+			name:       "drifted with wildcards",
+			categories: []string{analyser.CategoryDrifted},
+			wildcards:  []string{"aws_iam_role"},
+			want: []string{
+				"aws_iam_role.*.Description",
+				`aws_iam_role.*.Tags.kubernetes\.io/role`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewDriftIgnoreGenerator(tt.categories, tt.wildcards)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.want, g.Lines(fakeDriftIgnoreAnalysis()))
+		})
+	}
+}
+
+func TestNewDriftIgnoreGenerator_Invalid(t *testing.T) {
+	_, err := NewDriftIgnoreGenerator([]string{"missing"}, nil)
+	assert.EqualError(t, err, "unsupported drift category missing")
+
+	_, err = NewDriftIgnoreGenerator(analyser.Categories(), []string{"aws_["})
+	assert.EqualError(t, err, "invalid resource type pattern aws_[: syntax error in pattern")
+}
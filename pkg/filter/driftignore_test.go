@@ -238,6 +238,16 @@ func TestDriftIgnore_IsFieldIgnored(t *testing.T) {
 					Path: []string{"Struct", "Bar"},
 					Want: true,
 				},
+				{
+					Res:  resource2.FakeResource{Type: "res_type", Id: "dotted_field_drift_ignored"},
+					Path: []string{"Tags", "kubernetes.io/role"},
+					Want: true,
+				},
+				{
+					Res:  resource2.FakeResource{Type: "res_type", Id: "dotted_field_drift_ignored"},
+					Path: []string{"Tags", "kubernetes"},
+					Want: false,
+				},
 			},
 		},
 	}
@@ -289,3 +299,44 @@ func Test_escapableSplit(t *testing.T) {
 		})
 	}
 }
+
+func TestDriftIgnoreLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		parts []string
+		want  string
+	}{
+		{
+			name:  "resource",
+			parts: []string{"aws_s3_bucket", "my-bucket"},
+			want:  "aws_s3_bucket.my-bucket",
+		},
+		{
+			name:  "wildcard",
+			parts: []string{"aws_instance", "*"},
+			want:  "aws_instance.*",
+		},
+		{
+			name:  "dots",
+			parts: []string{"aws_route53_record", "Z1035360GLIB82T1EH2G_test.example.com_A", "records"},
+			want:  `aws_route53_record.Z1035360GLIB82T1EH2G_test\.example\.com_A.records`,
+		},
+		{
+			name:  "backslashes",
+			parts: []string{"resource_type", `resource_id_containing\backslash`, "path", "to", `backslash\FieldName`},
+			want:  `resource_type.resource_id_containing\\backslash.path.to.backslash\\FieldName`,
+		},
+		{
+			name:  "dots and backslashes",
+			parts: []string{"resource_type", `a\b.c`, "tags", "kubernetes.io/role"},
+			want:  `resource_type.a\\b\.c.tags.kubernetes\.io/role`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DriftIgnoreLine(tt.parts...)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.parts, readDriftIgnoreLine(got))
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package filter
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+// TypeFilter selects the resource types to scan from patterns of types to include and exclude,
+// patterns are resource types that may contain wildcards (e.g. aws_s3_*)
+type TypeFilter struct {
+	include []string
+	exclude []string
+}
+
+// NewTypeFilter returns a filter selecting types matching an include pattern, or every type when none is given,
+// and not matching any exclude pattern
+func NewTypeFilter(include, exclude []string) (*TypeFilter, error) {
+	for _, pattern := range append(append([]string{}, include...), exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid resource type pattern %s: %w", pattern, err)
+		}
+	}
+	return &TypeFilter{include, exclude}, nil
+}
+
+// IsTypeSelected returns true when resources of the given type have to be scanned, a nil filter selects every type
+func (f *TypeFilter) IsTypeSelected(ty string) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.include) > 0 && !matchAny(f.include, ty) {
+		return false
+	}
+	return !matchAny(f.exclude, ty)
+}
+
+// Run returns resources of selected types only
+func (f *TypeFilter) Run(resources []resource.Resource) []resource.Resource {
+	if f == nil {
+		return resources
+	}
+	selected := make([]resource.Resource, 0, len(resources))
+	for _, res := range resources {
+		if f.IsTypeSelected(res.TerraformType()) {
+			selected = append(selected, res)
+		}
+	}
+	return selected
+}
+
+// UnknownPatterns returns patterns matching none of the given types, they are most likely typos
+func (f *TypeFilter) UnknownPatterns(types []string) []string {
+	unknown := make([]string, 0)
+	for _, pattern := range append(append([]string{}, f.include...), f.exclude...) {
+		known := false
+		for _, ty := range types {
+			if matchAny([]string{pattern}, ty) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			unknown = append(unknown, pattern)
+		}
+	}
+	return unknown
+}
+
+func matchAny(patterns []string, ty string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, ty); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	testresource "github.com/cloudskiff/driftctl/test/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeFilter_IsTypeSelected(t *testing.T) {
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		selected []string
+		ignored  []string
+	}{
+		{
+			name:     "every type is selected by default",
+			selected: []string{"aws_s3_bucket", "aws_iam_user"},
+		},
+		{
+			name:     "include types",
+			include:  []string{"aws_s3_*", "aws_iam_user"},
+			selected: []string{"aws_s3_bucket", "aws_s3_bucket_policy", "aws_iam_user"},
+			ignored:  []string{"aws_iam_user_policy", "aws_instance"},
+		},
+		{
+			name:     "exclude types",
+			exclude:  []string{"aws_iam_*"},
+			selected: []string{"aws_s3_bucket", "aws_instance"},
+			ignored:  []string{"aws_iam_user", "aws_iam_role"},
+		},
+		{
+			name:     "exclude types from included ones",
+			include:  []string{"aws_s3_*"},
+			exclude:  []string{"aws_s3_bucket_policy"},
+			selected: []string{"aws_s3_bucket", "aws_s3_bucket_metric"},
+			ignored:  []string{"aws_s3_bucket_policy", "aws_iam_user"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewTypeFilter(tt.include, tt.exclude)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, ty := range tt.selected {
+				assert.True(t, f.IsTypeSelected(ty), ty)
+			}
+			for _, ty := range tt.ignored {
+				assert.False(t, f.IsTypeSelected(ty), ty)
+			}
+		})
+	}
+}
+
+func TestTypeFilter_Nil(t *testing.T) {
+	var f *TypeFilter
+	resources := []resource.Resource{testresource.FakeResource{Id: "foo"}}
+
+	assert.True(t, f.IsTypeSelected("aws_s3_bucket"))
+	assert.Equal(t, resources, f.Run(resources))
+}
+
+func TestTypeFilter_Run(t *testing.T) {
+	f, err := NewTypeFilter([]string{"aws_s3_bucket"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := f.Run([]resource.Resource{
+		testresource.FakeResource{Id: "bucket", Type: "aws_s3_bucket"},
+		testresource.FakeResource{Id: "user", Type: "aws_iam_user"},
+	})
+	assert.Equal(t, []resource.Resource{
+		testresource.FakeResource{Id: "bucket", Type: "aws_s3_bucket"},
+	}, got)
+}
+
+func TestTypeFilter_UnknownPatterns(t *testing.T) {
+	f, err := NewTypeFilter([]string{"aws_s3_*", "aws_foobar"}, []string{"aws_s3_bucket_polcy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"aws_foobar", "aws_s3_bucket_polcy"}, f.UnknownPatterns([]string{"aws_s3_bucket", "aws_s3_bucket_policy"}))
+}
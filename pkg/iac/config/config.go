@@ -1,7 +1,20 @@
 package config
 
+import "fmt"
+
 type SupplierConfig struct {
-	Key     string
-	Backend string
-	Path    string
+	Key                string
+	Backend            string
+	Path               string
+	Workspace          string // Terraform workspace to read, "*" to read every workspace of the state
+	WorkspaceKeyPrefix string // Prefix of the keys of non-default workspaces (e.g. env:)
+	Account            string // ID of the cloud account resources of the source belong to, empty if unknown
+}
+
+func (c SupplierConfig) String() string {
+	scheme := c.Key
+	if c.Backend != "" {
+		scheme = fmt.Sprintf("%s+%s", scheme, c.Backend)
+	}
+	return fmt.Sprintf("%s://%s", scheme, c.Path)
 }
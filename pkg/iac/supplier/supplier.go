@@ -15,6 +15,7 @@ import (
 
 var supportedSuppliers = []string{
 	state.TerraformStateReaderSupplier,
+	state.TerraformPlanReaderSupplier,
 }
 
 func IsSupplierSupported(supplierKey string) bool {
@@ -26,8 +27,9 @@ func IsSupplierSupported(supplierKey string) bool {
 	return false
 }
 
-func GetIACSupplier(configs []config.SupplierConfig) (resource.Supplier, error) {
-	chainSupplier := resource.NewChainSupplier()
+// GetIACSupplier returns a supplier reading every IaC source, at most parallelism sources are read at once
+func GetIACSupplier(configs []config.SupplierConfig, parallelism int) (resource.Supplier, error) {
+	chainSupplier := resource.NewChainSupplier(parallelism)
 	for _, config := range configs {
 		if !IsSupplierSupported(config.Key) {
 			return nil, fmt.Errorf("Unsupported supplier '%s'", config.Key)
@@ -38,6 +40,8 @@ func GetIACSupplier(configs []config.SupplierConfig) (resource.Supplier, error)
 		switch config.Key {
 		case state.TerraformStateReaderSupplier:
 			supplier, err = state.NewReader(config)
+		case state.TerraformPlanReaderSupplier:
+			supplier, err = state.NewPlanReader(config)
 		default:
 			return nil, fmt.Errorf("Unsupported supplier '%s'", config.Key)
 		}
@@ -57,15 +61,94 @@ func GetIACSupplier(configs []config.SupplierConfig) (resource.Supplier, error)
 	return chainSupplier, nil
 }
 
+// ExpandSupplierConfigs replaces every config whose path is a glob or a prefix
+// by one config per matching state, then every config targeting workspaces
+// by one config per workspace
+func ExpandSupplierConfigs(configs []config.SupplierConfig) ([]config.SupplierConfig, error) {
+	expanded := make([]config.SupplierConfig, 0, len(configs))
+	for _, cfg := range configs {
+		paths, err := expandPaths(cfg)
+		if err != nil {
+			return nil, err
+		}
+		for _, pathCfg := range paths {
+			workspaces, err := expandWorkspaces(pathCfg)
+			if err != nil {
+				return nil, err
+			}
+			expanded = append(expanded, workspaces...)
+		}
+	}
+	return expanded, nil
+}
+
+func expandPaths(cfg config.SupplierConfig) ([]config.SupplierConfig, error) {
+	enumerator, err := backend.GetEnumerator(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if enumerator == nil {
+		return []config.SupplierConfig{cfg}, nil
+	}
+
+	paths, err := enumerator.Enumerate()
+	if err != nil {
+		return nil, err
+	}
+	expanded := make([]config.SupplierConfig, 0, len(paths))
+	for _, path := range paths {
+		expandedCfg := cfg
+		expandedCfg.Path = path
+		logrus.WithFields(logrus.Fields{
+			"pattern": cfg.String(),
+			"source":  expandedCfg.String(),
+		}).Debug("Expanded IAC source")
+		expanded = append(expanded, expandedCfg)
+	}
+	logrus.Infof("Found %d IaC source(s) matching %s", len(paths), cfg.String())
+	return expanded, nil
+}
+
+func expandWorkspaces(cfg config.SupplierConfig) ([]config.SupplierConfig, error) {
+	if cfg.Workspace == "" || !backend.SupportsWorkspaces(cfg.Backend) {
+		return []config.SupplierConfig{cfg}, nil
+	}
+
+	workspaces := []string{cfg.Workspace}
+	enumerator, err := backend.GetWorkspaceEnumerator(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if enumerator != nil {
+		workspaces, err = enumerator.Enumerate()
+		if err != nil {
+			return nil, err
+		}
+		logrus.Infof("Found %d workspace(s) for %s", len(workspaces), cfg.String())
+	}
+
+	expanded := make([]config.SupplierConfig, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		expandedCfg := cfg
+		expandedCfg.Workspace = workspace
+		expandedCfg.Path = backend.WorkspacePath(cfg, workspace)
+		logrus.WithFields(logrus.Fields{
+			"workspace": workspace,
+			"source":    expandedCfg.String(),
+		}).Debug("Expanded IAC source workspace")
+		expanded = append(expanded, expandedCfg)
+	}
+	return expanded, nil
+}
+
 func GetSupportedSuppliers() []string {
 	return supportedSuppliers
 }
 
 func GetSupportedSchemes() []string {
-	schemes := []string{
-		"tfstate://",
-	}
+	schemes := make([]string, 0)
 	for _, supplier := range supportedSuppliers {
+		schemes = append(schemes, fmt.Sprintf("%s://", supplier))
 		for _, backend := range backend.GetSupportedBackends() {
 			schemes = append(schemes, fmt.Sprintf("%s+%s://", supplier, backend))
 		}
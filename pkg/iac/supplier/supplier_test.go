@@ -76,7 +76,7 @@ func TestGetIACSupplier(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := GetIACSupplier(tt.args.config)
+			_, err := GetIACSupplier(tt.args.config, 0)
 			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
 				t.Errorf("GetIACSupplier() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -90,9 +90,87 @@ func TestGetSupportedSchemes(t *testing.T) {
 	want := []string{
 		"tfstate://",
 		"tfstate+s3://",
+		"tfstate+http://",
+		"tfstate+https://",
+		"tfstate+tfcloud://",
+		"tfplan://",
+		"tfplan+s3://",
+		"tfplan+http://",
+		"tfplan+https://",
+		"tfplan+tfcloud://",
 	}
 
 	if got := GetSupportedSchemes(); !reflect.DeepEqual(got, want) {
 		t.Errorf("GetSupportedSchemes() = %v, want %v", got, want)
 	}
 }
+
+func TestExpandSupplierConfigs(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs []config.SupplierConfig
+		want    []config.SupplierConfig
+		wantErr error
+	}{
+		{
+			name: "test configs without pattern are kept",
+			configs: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "", Path: "terraform.tfstate"},
+				{Key: "tfstate", Backend: "s3", Path: "bucket/terraform.tfstate"},
+			},
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "", Path: "terraform.tfstate"},
+				{Key: "tfstate", Backend: "s3", Path: "bucket/terraform.tfstate"},
+			},
+		},
+		{
+			name: "test glob is expanded",
+			configs: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "", Path: "terraform.tfstate"},
+				{Key: "tfstate", Backend: "", Path: "../terraform/state/backend/testdata/states/prod/**/*.tfstate"},
+			},
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "", Path: "terraform.tfstate"},
+				{Key: "tfstate", Backend: "", Path: "../terraform/state/backend/testdata/states/prod/network/terraform.tfstate"},
+				{Key: "tfstate", Backend: "", Path: "../terraform/state/backend/testdata/states/prod/terraform.tfstate"},
+			},
+		},
+		{
+			name: "test named workspace",
+			configs: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "s3", Path: "bucket/terraform.tfstate", Workspace: "staging"},
+				{Key: "tfstate", Backend: "s3", Path: "bucket/terraform.tfstate", Workspace: "default"},
+				{Key: "tfstate", Backend: "", Path: "terraform.tfstate", Workspace: "staging"},
+			},
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "s3", Path: "bucket/env:/staging/terraform.tfstate", Workspace: "staging"},
+				{Key: "tfstate", Backend: "s3", Path: "bucket/terraform.tfstate", Workspace: "default"},
+				{Key: "tfstate", Backend: "", Path: "terraform.tfstate", Workspace: "staging"},
+			},
+		},
+		{
+			name: "test glob without match",
+			configs: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "", Path: "testdata/**/*.tfstate"},
+			},
+			wantErr: fmt.Errorf("No state file found matching testdata/**/*.tfstate"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandSupplierConfigs(tt.configs)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Errorf("ExpandSupplierConfigs() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpandSupplierConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,6 +10,9 @@ import (
 var supportedBackends = []string{
 	backendFile,
 	backendS3,
+	backendHTTP,
+	backendHTTPS,
+	backendTFCloud,
 }
 
 type Backend io.ReadCloser
@@ -37,6 +40,10 @@ func GetBackend(config config.SupplierConfig) (Backend, error) {
 		return NewFileReader(config.Path)
 	case backendS3:
 		return NewS3Reader(config.Path)
+	case backendHTTP, backendHTTPS:
+		return NewHTTPReader(fmt.Sprintf("%s://%s", backend, config.Path))
+	case backendTFCloud:
+		return NewTFCloudReader(config.Path)
 	default:
 		return nil, fmt.Errorf("Unsupported backend '%s'", backend)
 	}
@@ -0,0 +1,44 @@
+package backend
+
+import (
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+)
+
+// Enumerator lists every state path matching a path pattern of a given backend
+type Enumerator interface {
+	Enumerate() ([]string, error)
+}
+
+// GetEnumerator returns an enumerator when the path of the config should be expanded
+// into several states (e.g. glob or S3 prefix), nil otherwise
+func GetEnumerator(config config.SupplierConfig) (Enumerator, error) {
+	switch config.Backend {
+	case backendFile:
+		if !HasGlob(config.Path) {
+			return nil, nil
+		}
+		return NewFileEnumerator(config.Path), nil
+	case backendS3:
+		if !HasGlob(config.Path) && !strings.HasSuffix(config.Path, "/") {
+			return nil, nil
+		}
+		return NewS3Enumerator(config.Path)
+	default:
+		return nil, nil
+	}
+}
+
+// HasGlob returns true if the path contains glob meta characters
+func HasGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[{")
+}
+
+// globPrefix returns the part of a glob pattern before the first meta character
+func globPrefix(pattern string) string {
+	if i := strings.IndexAny(pattern, "*?[{"); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
@@ -0,0 +1,40 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bmatcuk/doublestar"
+)
+
+type FileEnumerator struct {
+	pattern string
+}
+
+func NewFileEnumerator(pattern string) *FileEnumerator {
+	return &FileEnumerator{pattern}
+}
+
+func (e *FileEnumerator) Enumerate() ([]string, error) {
+	matches, err := doublestar.Glob(e.pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(matches))
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			return nil, err
+		}
+		if info.IsDir() {
+			continue
+		}
+		files = append(files, match)
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("No state file found matching %s", e.pattern)
+	}
+	return files, nil
+}
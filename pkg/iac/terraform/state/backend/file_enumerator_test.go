@@ -0,0 +1,57 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileEnumerator_Enumerate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "recursive glob",
+			pattern: "testdata/states/**/*.tfstate",
+			want: []string{
+				"testdata/states/prod/network/terraform.tfstate",
+				"testdata/states/prod/terraform.tfstate",
+				"testdata/states/staging/terraform.tfstate",
+			},
+		},
+		{
+			name:    "simple glob",
+			pattern: "testdata/states/*/terraform.tfstate",
+			want: []string{
+				"testdata/states/prod/terraform.tfstate",
+				"testdata/states/staging/terraform.tfstate",
+			},
+		},
+		{
+			name:    "directories are ignored",
+			pattern: "testdata/states/prod/*",
+			want: []string{
+				"testdata/states/prod/terraform.tfstate",
+			},
+		},
+		{
+			name:    "no match",
+			pattern: "testdata/states/**/*.json",
+			wantErr: "No state file found matching testdata/states/**/*.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFileEnumerator(tt.pattern).Enumerate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package backend
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const backendHTTP = "http"
+const backendHTTPS = "https"
+
+// Environment variables used to authenticate against HTTP backends
+const (
+	HTTPUsernameEnv = "DCTL_TFSTATE_HTTP_USERNAME"
+	HTTPPasswordEnv = "DCTL_TFSTATE_HTTP_PASSWORD"
+	HTTPTokenEnv    = "DCTL_TFSTATE_HTTP_TOKEN"
+)
+
+// httpTimeout bounds each request reading a state, an unresponsive server must not hang the scan
+var httpTimeout = 5 * time.Minute
+
+type HTTPBackend struct {
+	request *http.Request
+	reader  io.ReadCloser
+	client  *http.Client
+}
+
+func NewHTTPReader(url string) (*HTTPBackend, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both would be sent in the Authorization header, where only one of them can be
+	username, token := os.Getenv(HTTPUsernameEnv), os.Getenv(HTTPTokenEnv)
+	if username != "" && token != "" {
+		return nil, fmt.Errorf("%s and %s cannot be used together", HTTPUsernameEnv, HTTPTokenEnv)
+	}
+
+	if username != "" {
+		logrus.WithFields(logrus.Fields{
+			"username": username,
+		}).Debug("Using basic auth for HTTP backend")
+		req.SetBasicAuth(username, os.Getenv(HTTPPasswordEnv))
+	}
+
+	if token != "" {
+		logrus.Debug("Using bearer token for HTTP backend")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	return &HTTPBackend{
+		request: req,
+		client:  &http.Client{Timeout: httpTimeout},
+	}, nil
+}
+
+func (h *HTTPBackend) Read(p []byte) (n int, err error) {
+	if h.reader == nil {
+		res, err := h.client.Do(h.request)
+		if err != nil {
+			return 0, err
+		}
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			res.Body.Close()
+			return 0, fmt.Errorf("Error reading state from %s: %s", h.request.URL.Redacted(), res.Status)
+		}
+		h.reader = res.Body
+	}
+	return h.reader.Read(p)
+}
+
+func (h *HTTPBackend) Close() error {
+	if h.reader != nil {
+		return h.reader.Close()
+	}
+	return fmt.Errorf("Unable to close reader as nothing was opened")
+}
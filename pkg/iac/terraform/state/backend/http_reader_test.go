@@ -0,0 +1,133 @@
+package backend
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPBackend_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		status  int
+		assert  func(*testing.T, *http.Request)
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "read state without auth",
+			status: http.StatusOK,
+			assert: func(t *testing.T, r *http.Request) {
+				assert.Empty(t, r.Header.Get("Authorization"))
+				assert.Equal(t, "/path/to/terraform.tfstate", r.URL.Path)
+			},
+			want: `{"version": 4}`,
+		},
+		{
+			name: "read state with basic auth",
+			env: map[string]string{
+				HTTPUsernameEnv: "user",
+				HTTPPasswordEnv: "secret",
+			},
+			status: http.StatusOK,
+			assert: func(t *testing.T, r *http.Request) {
+				username, password, ok := r.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "user", username)
+				assert.Equal(t, "secret", password)
+			},
+			want: `{"version": 4}`,
+		},
+		{
+			name: "read state with bearer token",
+			env: map[string]string{
+				HTTPTokenEnv: "token",
+			},
+			status: http.StatusOK,
+			assert: func(t *testing.T, r *http.Request) {
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			},
+			want: `{"version": 4}`,
+		},
+		{
+			name:    "error status",
+			status:  http.StatusForbidden,
+			assert:  func(t *testing.T, r *http.Request) {},
+			wantErr: "403 Forbidden",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{HTTPUsernameEnv, HTTPPasswordEnv, HTTPTokenEnv} {
+				os.Unsetenv(key)
+			}
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+				defer os.Unsetenv(key)
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tt.assert(t, r)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"version": 4}`))
+			}))
+			defer server.Close()
+
+			reader, err := NewHTTPReader(server.URL + "/path/to/terraform.tfstate")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ioutil.ReadAll(reader)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.Nil(t, reader.Close())
+		})
+	}
+}
+
+func TestHTTPBackend_CloseWithoutRead(t *testing.T) {
+	reader, err := NewHTTPReader("http://localhost/terraform.tfstate")
+	assert.Nil(t, err)
+	assert.EqualError(t, reader.Close(), "Unable to close reader as nothing was opened")
+}
+
+func TestNewHTTPReader_BasicAuthAndToken(t *testing.T) {
+	os.Setenv(HTTPUsernameEnv, "user")
+	defer os.Unsetenv(HTTPUsernameEnv)
+	os.Setenv(HTTPTokenEnv, "token")
+	defer os.Unsetenv(HTTPTokenEnv)
+
+	_, err := NewHTTPReader("http://localhost/terraform.tfstate")
+	assert.EqualError(t, err, "DCTL_TFSTATE_HTTP_USERNAME and DCTL_TFSTATE_HTTP_TOKEN cannot be used together")
+}
+
+func TestHTTPBackend_ReadTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { httpTimeout = timeout }(httpTimeout)
+	httpTimeout = 10 * time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	reader, err := NewHTTPReader(server.URL + "/terraform.tfstate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ioutil.ReadAll(reader)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Client.Timeout exceeded")
+}
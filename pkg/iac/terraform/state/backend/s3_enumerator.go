@@ -0,0 +1,79 @@
+package backend
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/bmatcuk/doublestar"
+)
+
+const tfstateExtension = ".tfstate"
+
+type S3Enumerator struct {
+	bucket   string
+	pattern  string
+	S3Client s3iface.S3API
+}
+
+func NewS3Enumerator(path string) (*S3Enumerator, error) {
+	bucketPath := strings.SplitN(path, "/", 2)
+	if len(bucketPath) < 2 || bucketPath[0] == "" {
+		return nil, fmt.Errorf("Unable to parse S3 path: %s. Must be BUCKET_NAME/PREFIX/ or BUCKET_NAME/PATTERN", path)
+	}
+
+	return &S3Enumerator{
+		bucket:   bucketPath[0],
+		pattern:  bucketPath[1],
+		S3Client: newS3Client(),
+	}, nil
+}
+
+// Enumerate lists objects under the prefix of the pattern.
+// A pattern ending with a slash matches every state file (*.tfstate) under it,
+// otherwise keys are matched against the glob pattern.
+func (e *S3Enumerator) Enumerate() ([]string, error) {
+	prefix := globPrefix(e.pattern)
+	input := &s3.ListObjectsV2Input{
+		Bucket: &e.bucket,
+		Prefix: &prefix,
+	}
+
+	keys := make([]string, 0)
+	var matchErr error
+	err := e.S3Client.ListObjectsV2Pages(input, func(output *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range output.Contents {
+			key := *object.Key
+			if strings.HasSuffix(key, "/") {
+				continue
+			}
+			if !HasGlob(e.pattern) {
+				if strings.HasSuffix(key, tfstateExtension) {
+					keys = append(keys, fmt.Sprintf("%s/%s", e.bucket, key))
+				}
+				continue
+			}
+			match, err := doublestar.Match(e.pattern, key)
+			if err != nil {
+				matchErr = err
+				return false
+			}
+			if match {
+				keys = append(keys, fmt.Sprintf("%s/%s", e.bucket, key))
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error listing states in s3 bucket '%s': %w", e.bucket, err)
+	}
+	if matchErr != nil {
+		return nil, matchErr
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("No state file found matching %s/%s", e.bucket, e.pattern)
+	}
+	return keys, nil
+}
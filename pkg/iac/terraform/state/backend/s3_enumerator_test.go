@@ -0,0 +1,111 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cloudskiff/driftctl/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestS3Enumerator_Enumerate(t *testing.T) {
+	objects := []*s3.Object{
+		{Key: aws.String("states/")},
+		{Key: aws.String("states/prod/terraform.tfstate")},
+		{Key: aws.String("states/prod/network/terraform.tfstate")},
+		{Key: aws.String("states/staging/terraform.tfstate")},
+		{Key: aws.String("states/staging/README.md")},
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantPrefix string
+		listErr    error
+		want       []string
+		wantErr    string
+	}{
+		{
+			name:       "prefix",
+			path:       "bucket/states/",
+			wantPrefix: "states/",
+			want: []string{
+				"bucket/states/prod/terraform.tfstate",
+				"bucket/states/prod/network/terraform.tfstate",
+				"bucket/states/staging/terraform.tfstate",
+			},
+		},
+		{
+			name:       "glob",
+			path:       "bucket/states/*/terraform.tfstate",
+			wantPrefix: "states/",
+			want: []string{
+				"bucket/states/prod/terraform.tfstate",
+				"bucket/states/staging/terraform.tfstate",
+			},
+		},
+		{
+			name:       "recursive glob",
+			path:       "bucket/states/prod/**/*.tfstate",
+			wantPrefix: "states/prod/",
+			want: []string{
+				"bucket/states/prod/terraform.tfstate",
+				"bucket/states/prod/network/terraform.tfstate",
+			},
+		},
+		{
+			name:       "no match",
+			path:       "bucket/states/**/*.json",
+			wantPrefix: "states/",
+			wantErr:    "No state file found matching bucket/states/**/*.json",
+		},
+		{
+			name:       "list error",
+			path:       "bucket/states/",
+			wantPrefix: "states/",
+			listErr:    errors.New("access denied"),
+			wantErr:    "Error listing states in s3 bucket 'bucket': access denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeS3 := &mocks.FakeS3{}
+			fakeS3.On(
+				"ListObjectsV2Pages",
+				&s3.ListObjectsV2Input{
+					Bucket: aws.String("bucket"),
+					Prefix: aws.String(tt.wantPrefix),
+				},
+				mock.MatchedBy(func(callback func(res *s3.ListObjectsV2Output, lastPage bool) bool) bool {
+					if tt.listErr != nil {
+						return true
+					}
+					callback(&s3.ListObjectsV2Output{Contents: objects}, true)
+					return true
+				}),
+			).Return(tt.listErr).Once()
+
+			enumerator, err := NewS3Enumerator(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			enumerator.S3Client = fakeS3
+
+			got, err := enumerator.Enumerate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewS3EnumeratorInvalid(t *testing.T) {
+	_, err := NewS3Enumerator("bucket")
+	assert.EqualError(t, err, "Unable to parse S3 path: bucket. Must be BUCKET_NAME/PREFIX/ or BUCKET_NAME/PATTERN")
+}
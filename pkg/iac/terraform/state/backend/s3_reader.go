@@ -5,8 +5,6 @@ import (
 	"io"
 	"strings"
 
-	"github.com/aws/aws-sdk-go/aws/session"
-
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 )
@@ -33,10 +31,7 @@ func NewS3Reader(path string) (*S3Backend, error) {
 		Key:    &key,
 		Bucket: &bucket,
 	}
-	sess := session.Must(session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-	}))
-	backend.S3Client = s3.New(sess)
+	backend.S3Client = newS3Client()
 	return &backend, nil
 }
 
@@ -0,0 +1,27 @@
+package backend
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cloudskiff/driftctl/pkg/remote/aws/readonly"
+)
+
+var s3Config = aws.Config{}
+
+// SetS3Endpoint makes S3 states being read from a custom endpoint (e.g. an S3 compatible storage or emulator),
+// an empty endpoint restores the default AWS endpoint
+func SetS3Endpoint(endpoint string, forcePathStyle bool) {
+	s3Config = aws.Config{S3ForcePathStyle: aws.Bool(forcePathStyle)}
+	if endpoint != "" {
+		s3Config.Endpoint = aws.String(endpoint)
+	}
+}
+
+// newS3Client creates a client of the S3 backend, guarded to only call read-only operations
+func newS3Client() *s3.S3 {
+	sess := readonly.Guard(session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})))
+	return s3.New(sess, &s3Config)
+}
@@ -0,0 +1,42 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetS3Endpoint(t *testing.T) {
+	defer SetS3Endpoint("", false)
+
+	SetS3Endpoint("http://localhost:4566", true)
+	reader, err := NewS3Reader("bucket/terraform.tfstate")
+	assert.Nil(t, err)
+	client := reader.S3Client.(*s3.S3)
+	assert.Equal(t, "http://localhost:4566", client.Endpoint)
+	assert.True(t, aws.BoolValue(client.Config.S3ForcePathStyle))
+
+	SetS3Endpoint("", false)
+	reader, err = NewS3Reader("bucket/terraform.tfstate")
+	assert.Nil(t, err)
+	client = reader.S3Client.(*s3.S3)
+	assert.NotEqual(t, "http://localhost:4566", client.Endpoint)
+	assert.False(t, aws.BoolValue(client.Config.S3ForcePathStyle))
+}
+
+func TestNewS3Client_ReadOnly(t *testing.T) {
+	client := newS3Client()
+
+	req, _ := client.PutObjectRequest(&s3.PutObjectInput{Bucket: aws.String("bucket"), Key: aws.String("terraform.tfstate")})
+	err := req.Build()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "s3:PutObject is blocked")
+
+	req, _ = client.GetObjectRequest(&s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String("terraform.tfstate")})
+	err = req.Build()
+	if err != nil {
+		assert.NotContains(t, err.Error(), "is blocked")
+	}
+}
@@ -0,0 +1,128 @@
+package backend
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+)
+
+const (
+	DefaultWorkspace          = "default"
+	DefaultWorkspaceKeyPrefix = "env:"
+	AllWorkspaces             = "*"
+)
+
+// SupportsWorkspaces returns true if states of the backend can be split in Terraform workspaces
+func SupportsWorkspaces(backend string) bool {
+	return backend == backendS3
+}
+
+// GetWorkspaceEnumerator returns an enumerator listing the workspaces of a state
+// when every workspace has to be read, nil otherwise
+func GetWorkspaceEnumerator(config config.SupplierConfig) (Enumerator, error) {
+	if !SupportsWorkspaces(config.Backend) || config.Workspace != AllWorkspaces {
+		return nil, nil
+	}
+	return NewS3WorkspaceEnumerator(config.Path, config.WorkspaceKeyPrefix)
+}
+
+// WorkspacePath returns the path of the state of a given workspace,
+// following the layout of the Terraform S3 backend: <workspace_key_prefix>/<workspace>/<key>
+func WorkspacePath(config config.SupplierConfig, workspace string) string {
+	if !SupportsWorkspaces(config.Backend) || workspace == DefaultWorkspace {
+		return config.Path
+	}
+	bucketKey := strings.SplitN(config.Path, "/", 2)
+	if len(bucketKey) < 2 {
+		return config.Path
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", bucketKey[0], workspaceKeyPrefix(config.WorkspaceKeyPrefix), workspace, bucketKey[1])
+}
+
+func workspaceKeyPrefix(prefix string) string {
+	if prefix == "" {
+		return DefaultWorkspaceKeyPrefix
+	}
+	return strings.Trim(prefix, "/")
+}
+
+type S3WorkspaceEnumerator struct {
+	bucket    string
+	key       string
+	keyPrefix string
+	S3Client  s3iface.S3API
+}
+
+func NewS3WorkspaceEnumerator(path, keyPrefix string) (*S3WorkspaceEnumerator, error) {
+	bucketKey := strings.SplitN(path, "/", 2)
+	if len(bucketKey) < 2 || bucketKey[0] == "" || bucketKey[1] == "" {
+		return nil, fmt.Errorf("Unable to parse S3 path: %s. Must be BUCKET_NAME/PATH/TO/OBJECT", path)
+	}
+
+	return &S3WorkspaceEnumerator{
+		bucket:    bucketKey[0],
+		key:       bucketKey[1],
+		keyPrefix: workspaceKeyPrefix(keyPrefix),
+		S3Client:  newS3Client(),
+	}, nil
+}
+
+// Enumerate returns the name of every workspace having a state for the key,
+// including the default workspace when the key itself exists
+func (e *S3WorkspaceEnumerator) Enumerate() ([]string, error) {
+	workspaces := make([]string, 0)
+
+	defaultExists := false
+	err := e.list(e.key, func(key string) {
+		if key == e.key {
+			defaultExists = true
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if defaultExists {
+		workspaces = append(workspaces, DefaultWorkspace)
+	}
+
+	prefix := e.keyPrefix + "/"
+	suffix := "/" + e.key
+	err = e.list(prefix, func(key string) {
+		if !strings.HasSuffix(key, suffix) {
+			return
+		}
+		workspace := strings.TrimSuffix(strings.TrimPrefix(key, prefix), suffix)
+		if workspace == "" || strings.Contains(workspace, "/") {
+			return
+		}
+		workspaces = append(workspaces, workspace)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(workspaces) == 0 {
+		return nil, fmt.Errorf("No workspace found for state %s/%s", e.bucket, e.key)
+	}
+	return workspaces, nil
+}
+
+func (e *S3WorkspaceEnumerator) list(prefix string, fn func(key string)) error {
+	input := &s3.ListObjectsV2Input{
+		Bucket: &e.bucket,
+		Prefix: &prefix,
+	}
+	err := e.S3Client.ListObjectsV2Pages(input, func(output *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range output.Contents {
+			fn(*object.Key)
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return fmt.Errorf("Error listing workspaces in s3 bucket '%s': %w", e.bucket, err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cloudskiff/driftctl/mocks"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkspacePath(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    config.SupplierConfig
+		workspace string
+		want      string
+	}{
+		{
+			name:      "default workspace",
+			config:    config.SupplierConfig{Backend: "s3", Path: "bucket/path/to/state.tfstate"},
+			workspace: "default",
+			want:      "bucket/path/to/state.tfstate",
+		},
+		{
+			name:      "named workspace with default key prefix",
+			config:    config.SupplierConfig{Backend: "s3", Path: "bucket/path/to/state.tfstate"},
+			workspace: "staging",
+			want:      "bucket/env:/staging/path/to/state.tfstate",
+		},
+		{
+			name:      "named workspace with custom key prefix",
+			config:    config.SupplierConfig{Backend: "s3", Path: "bucket/state.tfstate", WorkspaceKeyPrefix: "workspaces/"},
+			workspace: "staging",
+			want:      "bucket/workspaces/staging/state.tfstate",
+		},
+		{
+			name:      "backend without workspaces",
+			config:    config.SupplierConfig{Backend: "", Path: "state.tfstate"},
+			workspace: "staging",
+			want:      "state.tfstate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, WorkspacePath(tt.config, tt.workspace))
+		})
+	}
+}
+
+func TestS3WorkspaceEnumerator_Enumerate(t *testing.T) {
+	tests := []struct {
+		name       string
+		keyPrefix  string
+		rootKeys   []string
+		envPrefix  string
+		envKeys    []string
+		envListErr error
+		want       []string
+		wantErr    string
+	}{
+		{
+			name:      "default and named workspaces",
+			rootKeys:  []string{"path/state.tfstate", "path/state.tfstate.backup"},
+			envPrefix: "env:/",
+			envKeys: []string{
+				"env:/staging/path/state.tfstate",
+				"env:/prod/path/state.tfstate",
+				"env:/prod/other/state.tfstate",
+				"env:/nested/dir/path/state.tfstate",
+			},
+			want: []string{"default", "staging", "prod"},
+		},
+		{
+			name:      "named workspaces only with custom key prefix",
+			keyPrefix: "workspaces",
+			envPrefix: "workspaces/",
+			envKeys:   []string{"workspaces/dev/path/state.tfstate"},
+			want:      []string{"dev"},
+		},
+		{
+			name:      "no workspace",
+			envPrefix: "env:/",
+			wantErr:   "No workspace found for state bucket/path/state.tfstate",
+		},
+		{
+			name:       "list error",
+			envPrefix:  "env:/",
+			envListErr: errors.New("access denied"),
+			wantErr:    "Error listing workspaces in s3 bucket 'bucket': access denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeS3 := &mocks.FakeS3{}
+			mockList(fakeS3, "path/state.tfstate", tt.rootKeys, nil)
+			mockList(fakeS3, tt.envPrefix, tt.envKeys, tt.envListErr)
+
+			enumerator, err := NewS3WorkspaceEnumerator("bucket/path/state.tfstate", tt.keyPrefix)
+			if err != nil {
+				t.Fatal(err)
+			}
+			enumerator.S3Client = fakeS3
+
+			got, err := enumerator.Enumerate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func mockList(fakeS3 *mocks.FakeS3, prefix string, keys []string, err error) {
+	objects := make([]*s3.Object, 0, len(keys))
+	for _, key := range keys {
+		objects = append(objects, &s3.Object{Key: aws.String(key)})
+	}
+	fakeS3.On(
+		"ListObjectsV2Pages",
+		&s3.ListObjectsV2Input{
+			Bucket: aws.String("bucket"),
+			Prefix: aws.String(prefix),
+		},
+		mock.MatchedBy(func(callback func(res *s3.ListObjectsV2Output, lastPage bool) bool) bool {
+			if err == nil {
+				callback(&s3.ListObjectsV2Output{Contents: objects}, true)
+			}
+			return true
+		}),
+	).Return(err)
+}
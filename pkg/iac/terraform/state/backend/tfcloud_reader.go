@@ -0,0 +1,203 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+	"github.com/hashicorp/terraform/command/cliconfig"
+	"github.com/sirupsen/logrus"
+)
+
+const backendTFCloud = "tfcloud"
+
+const defaultTFCloudHostname = "app.terraform.io"
+
+// TFCloudTokenEnv is the environment variable used to authenticate against Terraform Cloud or Enterprise.
+// When not set, the token is read from Terraform CLI credentials (e.g. ~/.terraformrc)
+const TFCloudTokenEnv = "DCTL_TFCLOUD_TOKEN"
+
+type TFCloudBackend struct {
+	endpoint     string
+	organization string
+	workspace    string
+	token        string
+	reader       io.ReadCloser
+	client       *http.Client
+}
+
+type tfCloudWorkspace struct {
+	Data struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+type tfCloudStateVersion struct {
+	Data struct {
+		Attributes struct {
+			HostedStateDownloadURL string `json:"hosted-state-download-url"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+func NewTFCloudReader(path string) (*TFCloudBackend, error) {
+	hostname, organization, workspace, err := parseTFCloudPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := getTFCloudToken(hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TFCloudBackend{
+		endpoint:     fmt.Sprintf("https://%s/api/v2", hostname),
+		organization: organization,
+		workspace:    workspace,
+		token:        token,
+		client:       &http.Client{Timeout: httpTimeout},
+	}, nil
+}
+
+// parseTFCloudPath accepts ORG/WORKSPACE for Terraform Cloud and HOSTNAME/ORG/WORKSPACE for Terraform Enterprise
+func parseTFCloudPath(path string) (string, string, string, error) {
+	parts := strings.Split(path, "/")
+	for _, part := range parts {
+		if part == "" {
+			parts = nil
+			break
+		}
+	}
+	switch len(parts) {
+	case 2:
+		return defaultTFCloudHostname, parts[0], parts[1], nil
+	case 3:
+		return parts[0], parts[1], parts[2], nil
+	default:
+		return "", "", "", fmt.Errorf("Unable to parse Terraform Cloud path: %s. Must be [HOSTNAME/]ORGANIZATION/WORKSPACE", path)
+	}
+}
+
+func getTFCloudToken(hostname string) (string, error) {
+	if token := os.Getenv(TFCloudTokenEnv); token != "" {
+		return token, nil
+	}
+
+	host, err := svchost.ForComparison(hostname)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, diags := cliconfig.LoadConfig()
+	if diags.HasErrors() {
+		return "", diags.Err()
+	}
+	credentials, err := cfg.CredentialsSource(nil)
+	if err != nil {
+		return "", err
+	}
+	hostCredentials, err := credentials.ForHost(host)
+	if err != nil {
+		return "", err
+	}
+	if hostCredentials == nil {
+		return "", fmt.Errorf("No token found for %s, set %s or add credentials to your Terraform CLI configuration", hostname, TFCloudTokenEnv)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"hostname": hostname,
+	}).Debug("Using Terraform CLI credentials")
+
+	return hostCredentials.Token(), nil
+}
+
+func (t *TFCloudBackend) Read(p []byte) (n int, err error) {
+	if t.reader == nil {
+		downloadURL, err := t.getStateDownloadURL()
+		if err != nil {
+			return 0, err
+		}
+		res, err := t.get(downloadURL)
+		if err != nil {
+			return 0, err
+		}
+		t.reader = res.Body
+	}
+	return t.reader.Read(p)
+}
+
+func (t *TFCloudBackend) Close() error {
+	if t.reader != nil {
+		return t.reader.Close()
+	}
+	return fmt.Errorf("Unable to close reader as nothing was opened")
+}
+
+func (t *TFCloudBackend) getStateDownloadURL() (string, error) {
+	workspace := tfCloudWorkspace{}
+	err := t.getJSON(
+		fmt.Sprintf("%s/organizations/%s/workspaces/%s", t.endpoint, url.PathEscape(t.organization), url.PathEscape(t.workspace)),
+		&workspace,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"organization": t.organization,
+		"workspace":    t.workspace,
+		"id":           workspace.Data.ID,
+	}).Debug("Found Terraform Cloud workspace")
+
+	stateVersion := tfCloudStateVersion{}
+	err = t.getJSON(
+		fmt.Sprintf("%s/workspaces/%s/current-state-version", t.endpoint, url.PathEscape(workspace.Data.ID)),
+		&stateVersion,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	downloadURL := stateVersion.Data.Attributes.HostedStateDownloadURL
+	if downloadURL == "" {
+		return "", fmt.Errorf("No state download URL found for workspace %s/%s", t.organization, t.workspace)
+	}
+	return downloadURL, nil
+}
+
+func (t *TFCloudBackend) getJSON(url string, v interface{}) error {
+	res, err := t.get(url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func (t *TFCloudBackend) get(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.token))
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+
+	res, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		res.Body.Close()
+		if res.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("Error reading state of workspace %s/%s from Terraform Cloud: workspace or state not found, check that it exists and your token has access to it", t.organization, t.workspace)
+		}
+		return nil, fmt.Errorf("Error reading state of workspace %s/%s from Terraform Cloud: %s", t.organization, t.workspace, res.Status)
+	}
+	return res, nil
+}
@@ -0,0 +1,154 @@
+package backend
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTFCloudReader(t *testing.T) {
+	os.Setenv(TFCloudTokenEnv, "token")
+	defer os.Unsetenv(TFCloudTokenEnv)
+
+	tests := []struct {
+		name    string
+		path    string
+		want    *TFCloudBackend
+		wantErr string
+	}{
+		{
+			name: "terraform cloud workspace",
+			path: "my-org/my-workspace",
+			want: &TFCloudBackend{
+				endpoint:     "https://app.terraform.io/api/v2",
+				organization: "my-org",
+				workspace:    "my-workspace",
+				token:        "token",
+				client:       &http.Client{Timeout: httpTimeout},
+			},
+		},
+		{
+			name: "terraform enterprise workspace",
+			path: "tfe.example.com/my-org/my-workspace",
+			want: &TFCloudBackend{
+				endpoint:     "https://tfe.example.com/api/v2",
+				organization: "my-org",
+				workspace:    "my-workspace",
+				token:        "token",
+				client:       &http.Client{Timeout: httpTimeout},
+			},
+		},
+		{
+			name:    "missing workspace",
+			path:    "my-org",
+			wantErr: "Unable to parse Terraform Cloud path: my-org. Must be [HOSTNAME/]ORGANIZATION/WORKSPACE",
+		},
+		{
+			name:    "empty workspace",
+			path:    "my-org/",
+			wantErr: "Unable to parse Terraform Cloud path: my-org/. Must be [HOSTNAME/]ORGANIZATION/WORKSPACE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTFCloudReader(tt.path)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewTFCloudReader_TokenFromCLIConfig(t *testing.T) {
+	os.Unsetenv(TFCloudTokenEnv)
+
+	dir, err := ioutil.TempDir("", "driftctl-tfcloud")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	configFile := path.Join(dir, ".terraformrc")
+	err = ioutil.WriteFile(configFile, []byte(`
+credentials "tfe.example.com" {
+  token = "cli-token"
+}
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("TF_CLI_CONFIG_FILE", configFile)
+	defer os.Unsetenv("TF_CLI_CONFIG_FILE")
+
+	got, err := NewTFCloudReader("tfe.example.com/my-org/my-workspace")
+	assert.Nil(t, err)
+	assert.Equal(t, "cli-token", got.token)
+
+	_, err = NewTFCloudReader("unknown.example.com/my-org/my-workspace")
+	assert.EqualError(t, err, "No token found for unknown.example.com, set DCTL_TFCLOUD_TOKEN or add credentials to your Terraform CLI configuration")
+}
+
+func TestTFCloudBackend_Read(t *testing.T) {
+	tests := []struct {
+		name      string
+		workspace string
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "read current state",
+			workspace: "my-workspace",
+			want:      `{"version": 4}`,
+		},
+		{
+			name:      "unknown workspace",
+			workspace: "unknown",
+			wantErr:   "Error reading state of workspace my-org/unknown from Terraform Cloud: workspace or state not found, check that it exists and your token has access to it",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+				switch r.URL.Path {
+				case "/api/v2/organizations/my-org/workspaces/my-workspace":
+					fmt.Fprint(w, `{"data": {"id": "ws-123"}}`)
+				case "/api/v2/workspaces/ws-123/current-state-version":
+					fmt.Fprintf(w, `{"data": {"attributes": {"hosted-state-download-url": "%s/download/sv-123"}}}`, server.URL)
+				case "/download/sv-123":
+					fmt.Fprint(w, `{"version": 4}`)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			reader := &TFCloudBackend{
+				endpoint:     server.URL + "/api/v2",
+				organization: "my-org",
+				workspace:    tt.workspace,
+				token:        "token",
+				client:       server.Client(),
+			}
+
+			got, err := ioutil.ReadAll(reader)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.Nil(t, reader.Close())
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/iac"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/cloudskiff/driftctl/pkg/remote/deserializer"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+	ctyconvert "github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const TerraformPlanReaderSupplier = "tfplan"
+
+// supportedPlanFormatVersions are the major versions of the JSON output format of terraform show driftctl can read,
+// Terraform only changes the major version on breaking changes
+var supportedPlanFormatVersions = []string{"0", "1"}
+
+// TerraformPlanReader reads resources from the JSON representation of a plan or a state,
+// as written by terraform show -json
+type TerraformPlanReader struct {
+	config        config.SupplierConfig
+	backend       backend.Backend
+	deserializers []deserializer.CTYDeserializer
+}
+
+// jsonPlan holds the parts of terraform show -json output driftctl needs.
+// A plan contains planned_values, a state only contains values
+type jsonPlan struct {
+	FormatVersion   string               `json:"format_version"`
+	PlannedValues   *jsonValues          `json:"planned_values"`
+	Values          *jsonValues          `json:"values"`
+	ResourceChanges []jsonResourceChange `json:"resource_changes"`
+}
+
+type jsonResourceChange struct {
+	Address string `json:"address"`
+	Change  struct {
+		Actions      []string               `json:"actions"`
+		AfterUnknown map[string]interface{} `json:"after_unknown"`
+	} `json:"change"`
+}
+
+type jsonValues struct {
+	RootModule jsonModule `json:"root_module"`
+}
+
+type jsonModule struct {
+	Address      string         `json:"address"`
+	Resources    []jsonResource `json:"resources"`
+	ChildModules []jsonModule   `json:"child_modules"`
+}
+
+type jsonResource struct {
+	Address      string          `json:"address"`
+	Mode         string          `json:"mode"`
+	Type         string          `json:"type"`
+	Name         string          `json:"name"`
+	ProviderName string          `json:"provider_name"`
+	Values       json.RawMessage `json:"values"`
+}
+
+func NewPlanReader(config config.SupplierConfig) (*TerraformPlanReader, error) {
+	b, err := backend.GetBackend(config)
+	if err != nil {
+		return nil, err
+	}
+	return &TerraformPlanReader{config: config, backend: b, deserializers: iac.Deserializers()}, nil
+}
+
+// checkPlanFormatVersion rejects JSON output of future major versions of Terraform, raw states have no format version
+// and are rejected later on
+func checkPlanFormatVersion(version string) error {
+	if version == "" {
+		return nil
+	}
+	major := strings.SplitN(version, ".", 2)[0]
+	for _, supported := range supportedPlanFormatVersions {
+		if major == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf(
+		"unsupported format version %s, driftctl supports versions %s.x",
+		version,
+		strings.Join(supportedPlanFormatVersions, ".x and "),
+	)
+}
+
+func (r *TerraformPlanReader) retrieve() (map[string][]stateValue, error) {
+	plan := jsonPlan{}
+	err := json.NewDecoder(r.backend).Decode(&plan)
+	defer r.backend.Close()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read terraform plan %s: %w", r.config.String(), err)
+	}
+	if err := checkPlanFormatVersion(plan.FormatVersion); err != nil {
+		return nil, fmt.Errorf("Unable to read terraform plan %s: %w", r.config.String(), err)
+	}
+
+	values := plan.PlannedValues
+	if values == nil {
+		values = plan.Values
+	}
+	if values == nil {
+		return nil, fmt.Errorf("Unable to read terraform plan %s: no planned_values nor values found, make sure it has been generated with terraform show -json", r.config.String())
+	}
+
+	// Planned values of resources the plan creates or replaces lack computed attributes, their ID is not known yet
+	unknownIds := make(map[string][]string)
+	for _, change := range plan.ResourceChanges {
+		if unknown, _ := change.Change.AfterUnknown["id"].(bool); unknown {
+			unknownIds[change.Address] = change.Change.Actions
+		}
+	}
+
+	resMap := make(map[string][]stateValue)
+	err = r.retrieveModule(values.RootModule, unknownIds, resMap)
+	if err != nil {
+		return nil, err
+	}
+	return resMap, nil
+}
+
+func (r *TerraformPlanReader) retrieveModule(module jsonModule, unknownIds map[string][]string, resMap map[string][]stateValue) error {
+	for _, planRes := range module.Resources {
+		if planRes.Mode != "managed" {
+			logrus.WithFields(logrus.Fields{
+				"mode":    planRes.Mode,
+				"address": planRes.Address,
+			}).Debug("Skipping plan entry as it is not a managed resource")
+			continue
+		}
+		if actions, unknown := unknownIds[planRes.Address]; unknown {
+			logrus.WithFields(logrus.Fields{
+				"address": planRes.Address,
+				"actions": strings.Join(actions, ","),
+			}).Warn("Skipping plan entry as its ID is only known after apply")
+			continue
+		}
+
+		// Provider name is fully qualified since terraform 0.13 (e.g. registry.terraform.io/hashicorp/aws)
+		providerType := planRes.ProviderName[strings.LastIndex(planRes.ProviderName, "/")+1:]
+		provider := terraform.Provider(providerType)
+		if provider == nil {
+			logrus.WithFields(logrus.Fields{
+				"providerKey": providerType,
+			}).Debug("Unsupported provider found in plan")
+			continue
+		}
+		schema, exists := provider.Schema()[planRes.Type]
+		if !exists {
+			logrus.WithFields(logrus.Fields{
+				"type": planRes.Type,
+			}).Debug("Unsupported resource type found in plan")
+			continue
+		}
+
+		value, err := decodeJSONValues(planRes.Values, schema.Block.ImpliedType())
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"address": planRes.Address,
+				"type":    planRes.Type,
+			}).Error("Unable to decode resource from plan")
+			return err
+		}
+		// Plans written without resource_changes may still hold resources without ID
+		if value.Type().HasAttribute("id") && value.GetAttr("id").IsNull() {
+			logrus.WithFields(logrus.Fields{
+				"address": planRes.Address,
+			}).Warn("Skipping plan entry as it has no ID")
+			continue
+		}
+
+		resMap[planRes.Type] = append(resMap[planRes.Type], stateValue{
+			value: value,
+			source: &resource.Source{
+				State:     r.config.String(),
+				Address:   planRes.Address,
+				Module:    module.Address,
+				Workspace: r.config.Workspace,
+			},
+		})
+	}
+
+	for _, child := range module.ChildModules {
+		if err := r.retrieveModule(child, unknownIds, resMap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeJSONValues decodes attributes of a resource, attributes unknown to the
+// supported provider schema are ignored so plans made with a newer provider can be read
+func decodeJSONValues(values json.RawMessage, ty cty.Type) (cty.Value, error) {
+	val, err := ctyjson.Unmarshal(values, ty)
+	if err == nil {
+		return val, nil
+	}
+
+	inputType, err := ctyjson.ImpliedType(values)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	input, err := ctyjson.Unmarshal(values, inputType)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return ctyconvert.Convert(input, ty)
+}
+
+func (r *TerraformPlanReader) Resources() ([]resource.Resource, error) {
+	logrus.WithFields(logrus.Fields{
+		"path":    r.config.Path,
+		"backend": r.config.Backend,
+	}).Debug("Starting plan reader supplier")
+	values, err := r.retrieve()
+	if err != nil {
+		return nil, err
+	}
+	return decode(r.config, r.deserializers, values)
+}
@@ -0,0 +1,86 @@
+package state
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/iac"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/cloudskiff/driftctl/test/goldenfile"
+	"github.com/cloudskiff/driftctl/test/mocks"
+	"github.com/r3labs/diff/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerraformPlanReader_Resources(t *testing.T) {
+	tests := []struct {
+		name     string
+		dirName  string
+		fileName string
+		wantErr  string
+	}{
+		{name: "Plan planned values", dirName: "modules", fileName: "plan.json"},
+		{name: "Plan creating a resource", dirName: "modules", fileName: "plan_create.json"},
+		{name: "State JSON values", dirName: "modules", fileName: "show.json"},
+		{name: "Raw state", dirName: "modules", fileName: "terraform.tfstate", wantErr: "no planned_values nor values found"},
+		{name: "Unsupported format version", dirName: "modules", fileName: "plan_v2.json", wantErr: "unsupported format version 2.0, driftctl supports versions 0.x and 1.x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terraform.AddProvider(terraform.AWS, mocks.NewMockedGoldenTFProvider(tt.dirName, nil, false))
+
+			planPath := path.Join(goldenfile.GoldenFilePath, tt.dirName, tt.fileName)
+			b, _ := backend.NewFileReader(planPath)
+			r := &TerraformPlanReader{
+				config: config.SupplierConfig{
+					Key:  TerraformPlanReaderSupplier,
+					Path: planPath,
+				},
+				backend:       b,
+				deserializers: iac.Deserializers(),
+			}
+
+			got, err := r.Resources()
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Resources read from a plan must match the ones read from the state it has been made with
+			file := goldenfile.ReadFile(tt.dirName, "result.golden.json")
+			var want []interface{}
+			if err := json.Unmarshal(file, &want); err != nil {
+				panic(err)
+			}
+			changelog, err := diff.Diff(convert(got), want)
+			if err != nil {
+				panic(err)
+			}
+			for _, change := range changelog {
+				t.Errorf("%s got = %v, want %v", strings.Join(change.Path, "."), change.From, change.To)
+			}
+
+			addresses := make([]string, 0, len(got))
+			for _, res := range got {
+				source := resource.GetSource(res)
+				assert.Equal(t, "tfplan://"+planPath, source.State)
+				addresses = append(addresses, source.Address)
+			}
+			assert.ElementsMatch(t, []string{
+				"aws_s3_bucket.root",
+				`module.logs.aws_s3_bucket.logs["eu"]`,
+				`module.logs.aws_s3_bucket.logs["us"]`,
+				"module.logs.module.archive[0].aws_s3_bucket.archive[0]",
+			}, addresses)
+		})
+	}
+}
@@ -1,11 +1,15 @@
 package state
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/cloudskiff/driftctl/pkg/iac"
 	"github.com/cloudskiff/driftctl/pkg/iac/config"
 	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
 	"github.com/cloudskiff/driftctl/pkg/remote/deserializer"
 	"github.com/cloudskiff/driftctl/pkg/resource"
+	resourceaws "github.com/cloudskiff/driftctl/pkg/resource/aws"
 	"github.com/cloudskiff/driftctl/pkg/terraform"
 	"github.com/hashicorp/terraform/addrs"
 	"github.com/hashicorp/terraform/states"
@@ -42,7 +46,13 @@ func NewReader(config config.SupplierConfig) (*TerraformStateReader, error) {
 	return &reader, nil
 }
 
-func (r *TerraformStateReader) retrieve() (map[string][]cty.Value, error) {
+// stateValue is a resource instance decoded from state along with where it has been found
+type stateValue struct {
+	value  cty.Value
+	source *resource.Source
+}
+
+func (r *TerraformStateReader) retrieve() (map[string][]stateValue, error) {
 
 	state, err := read(r.backend)
 	defer r.backend.Close()
@@ -50,61 +60,74 @@ func (r *TerraformStateReader) retrieve() (map[string][]cty.Value, error) {
 		return nil, err
 	}
 
-	stateResources := state.RootModule().Resources
-	resMap := make(map[string][]cty.Value)
-	for _, stateRes := range stateResources {
-		resName := stateRes.Addr.Resource.Name
-		resType := stateRes.Addr.Resource.Type
-		if stateRes.Addr.Resource.Mode != addrs.ManagedResourceMode {
-			logrus.WithFields(logrus.Fields{
-				"mode": stateRes.Addr.Resource.Mode,
-				"name": resName,
-				"type": resType,
-			}).Debug("Skipping state entry as it is not a managed resource")
-			continue
-		}
-		providerType := stateRes.ProviderConfig.Provider.Type
-		provider := terraform.Provider(providerType)
-		if provider == nil {
-			logrus.WithFields(logrus.Fields{
-				"providerKey": providerType,
-			}).Debug("Unsupported provider found in state")
-			continue
-		}
-		schema := provider.Schema()[stateRes.Addr.Resource.Type]
-		for _, instance := range stateRes.Instances {
-			decodedVal, err := instance.Current.Decode(schema.Block.ImpliedType())
-			if err != nil {
-				// Try to do a manual type conversion if we got a path error
-				// It will allow driftctl to read state generated with a superior version of provider
-				// than the actually supported one
-				// by ignoring new fields
-				_, isPathError := err.(cty.PathError)
-				if isPathError {
-					logrus.WithFields(logrus.Fields{
-						"name": resName,
-						"type": resType,
-						"err":  err.Error(),
-					}).Debug("Got a cty path error when deserializing state")
-
-					decodedVal, err = r.convertInstance(instance.Current, schema.Block.ImpliedType())
-				}
+	// Walk modules in a stable order, root module comes first as its key is empty
+	moduleKeys := make([]string, 0, len(state.Modules))
+	for key := range state.Modules {
+		moduleKeys = append(moduleKeys, key)
+	}
+	sort.Strings(moduleKeys)
 
-				if err != nil {
-					logrus.WithFields(logrus.Fields{
-						"name": resName,
-						"type": resType,
-					}).Error("Unable to decode resource from state")
-					return nil, err
-				}
+	resMap := make(map[string][]stateValue)
+	for _, moduleKey := range moduleKeys {
+		module := state.Modules[moduleKey]
+		for _, stateRes := range module.Resources {
+			resName := stateRes.Addr.Resource.Name
+			resType := stateRes.Addr.Resource.Type
+			if stateRes.Addr.Resource.Mode != addrs.ManagedResourceMode {
+				logrus.WithFields(logrus.Fields{
+					"mode":   stateRes.Addr.Resource.Mode,
+					"module": moduleKey,
+					"name":   resName,
+					"type":   resType,
+				}).Debug("Skipping state entry as it is not a managed resource")
+				continue
+			}
+			providerType := stateRes.ProviderConfig.Provider.Type
+			provider := terraform.Provider(providerType)
+			if provider == nil {
+				logrus.WithFields(logrus.Fields{
+					"providerKey": providerType,
+				}).Debug("Unsupported provider found in state")
+				continue
 			}
-			_, exists := resMap[stateRes.Addr.Resource.Type]
-			if !exists {
-				resMap[stateRes.Addr.Resource.Type] = []cty.Value{
-					decodedVal.Value,
+			schema := provider.Schema()[stateRes.Addr.Resource.Type]
+			for key, instance := range stateRes.Instances {
+				decodedVal, err := instance.Current.Decode(schema.Block.ImpliedType())
+				if err != nil {
+					// Try to do a manual type conversion if we got a path error
+					// It will allow driftctl to read state generated with a superior version of provider
+					// than the actually supported one
+					// by ignoring new fields
+					_, isPathError := err.(cty.PathError)
+					if isPathError {
+						logrus.WithFields(logrus.Fields{
+							"module": moduleKey,
+							"name":   resName,
+							"type":   resType,
+							"err":    err.Error(),
+						}).Debug("Got a cty path error when deserializing state")
+
+						decodedVal, err = r.convertInstance(instance.Current, schema.Block.ImpliedType())
+					}
+
+					if err != nil {
+						logrus.WithFields(logrus.Fields{
+							"module": moduleKey,
+							"name":   resName,
+							"type":   resType,
+						}).Error("Unable to decode resource from state")
+						return nil, err
+					}
 				}
-			} else {
-				resMap[stateRes.Addr.Resource.Type] = append(resMap[stateRes.Addr.Resource.Type], decodedVal.Value)
+				resMap[resType] = append(resMap[resType], stateValue{
+					value: decodedVal.Value,
+					source: &resource.Source{
+						State:     r.config.String(),
+						Address:   stateRes.Addr.Instance(key).String(),
+						Module:    module.Addr.String(),
+						Workspace: r.config.Workspace,
+					},
+				})
 			}
 		}
 	}
@@ -140,28 +163,51 @@ func (r *TerraformStateReader) convertInstance(instance *states.ResourceInstance
 	return instanceObj, nil
 }
 
-func (r *TerraformStateReader) decode(values map[string][]cty.Value) ([]resource.Resource, error) {
+func (r *TerraformStateReader) decode(values map[string][]stateValue) ([]resource.Resource, error) {
+	return decode(r.config, r.deserializers, values)
+}
+
+func decode(config config.SupplierConfig, deserializers []deserializer.CTYDeserializer, values map[string][]stateValue) ([]resource.Resource, error) {
 	results := make([]resource.Resource, 0)
-	for _, deserializer := range r.deserializers {
+	for _, deserializer := range deserializers {
 
 		typ := deserializer.HandledType().String()
-		vals, exists := values[typ]
+		stateVals, exists := values[typ]
 		if !exists {
 			logrus.WithFields(logrus.Fields{
-				"path":    r.config.Path,
-				"backend": r.config.Backend,
+				"path":    config.Path,
+				"backend": config.Backend,
 			}).Debugf("No resource of type %s found in state", typ)
 			continue
 		}
+		vals := make([]cty.Value, 0, len(stateVals))
+		for _, stateVal := range stateVals {
+			vals = append(vals, resourceaws.ConformValue(typ, stateVal.value))
+		}
 		decodedResources, err := deserializer.Deserialize(vals)
 		if err != nil {
 			logrus.Warnf("Could not read from decoder for %s: %+v", typ, err)
 			continue
 		}
-		for _, res := range decodedResources {
+		// Deserializers must return exactly one resource per value, in the same order, for sources to be attributed
+		if len(decodedResources) != len(stateVals) {
+			return nil, fmt.Errorf(
+				"deserializer of %s returned %d resources for %d values",
+				typ,
+				len(decodedResources),
+				len(stateVals),
+			)
+		}
+		for i, res := range decodedResources {
+			if meta := resource.GetMetadata(res); meta != nil {
+				meta.Source = stateVals[i].source
+				meta.Region = resource.RegionFromValue(stateVals[i].value)
+				meta.Account = config.Account
+			}
 			logrus.WithFields(logrus.Fields{
-				"path":    r.config.Path,
-				"backend": r.config.Backend,
+				"path":    config.Path,
+				"backend": config.Backend,
+				"address": stateVals[i].source.Address,
 				"id":      res.TerraformId(),
 				"type":    res.TerraformType(),
 			}).Debug("Found IAC resource")
@@ -8,14 +8,19 @@ import (
 	"testing"
 
 	"github.com/cloudskiff/driftctl/pkg/iac"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
 	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
 	"github.com/cloudskiff/driftctl/pkg/remote/aws"
+	"github.com/cloudskiff/driftctl/pkg/remote/deserializer"
 	"github.com/cloudskiff/driftctl/pkg/resource"
 	"github.com/cloudskiff/driftctl/pkg/terraform"
 	"github.com/cloudskiff/driftctl/test/goldenfile"
 	"github.com/cloudskiff/driftctl/test/mocks"
+	testresource "github.com/cloudskiff/driftctl/test/resource"
 
 	"github.com/r3labs/diff/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestReadStateValid(t *testing.T) {
@@ -35,6 +40,79 @@ func TestReadStateInvalid(t *testing.T) {
 	}
 }
 
+func TestTerraformStateReader_ResourcesSource(t *testing.T) {
+	terraform.AddProvider(terraform.AWS, mocks.NewMockedGoldenTFProvider("modules", nil, false))
+
+	statePath := path.Join(goldenfile.GoldenFilePath, "modules", "terraform.tfstate")
+	b, _ := backend.NewFileReader(statePath)
+	r := &TerraformStateReader{
+		config: config.SupplierConfig{
+			Key:  TerraformStateReaderSupplier,
+			Path: statePath,
+		},
+		backend:       b,
+		deserializers: iac.Deserializers(),
+	}
+
+	got, err := r.Resources()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := "tfstate://" + statePath
+	sources := make(map[string]resource.Source, len(got))
+	for _, res := range got {
+		sources[res.TerraformId()] = *resource.GetSource(res)
+	}
+	assert.Equal(t, map[string]resource.Source{
+		"driftctl-root-bucket": {
+			State:   state,
+			Address: "aws_s3_bucket.root",
+		},
+		"driftctl-logs-eu": {
+			State:   state,
+			Address: `module.logs.aws_s3_bucket.logs["eu"]`,
+			Module:  "module.logs",
+		},
+		"driftctl-logs-us": {
+			State:   state,
+			Address: `module.logs.aws_s3_bucket.logs["us"]`,
+			Module:  "module.logs",
+		},
+		"driftctl-archive": {
+			State:   state,
+			Address: "module.logs.module.archive[0].aws_s3_bucket.archive[0]",
+			Module:  "module.logs.module.archive[0]",
+		},
+	}, sources)
+}
+
+func TestTerraformStateReader_ResourcesWorkspace(t *testing.T) {
+	terraform.AddProvider(terraform.AWS, mocks.NewMockedGoldenTFProvider("modules", nil, false))
+
+	statePath := path.Join(goldenfile.GoldenFilePath, "modules", "terraform.tfstate")
+	b, _ := backend.NewFileReader(statePath)
+	r := &TerraformStateReader{
+		config: config.SupplierConfig{
+			Key:       TerraformStateReaderSupplier,
+			Path:      statePath,
+			Workspace: "staging",
+		},
+		backend:       b,
+		deserializers: iac.Deserializers(),
+	}
+
+	got, err := r.Resources()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, got)
+	for _, res := range got {
+		assert.Equal(t, "staging", resource.GetSource(res).Workspace)
+	}
+}
+
 func TestTerraformStateReader_Resources(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -73,6 +151,7 @@ func TestTerraformStateReader_Resources(t *testing.T) {
 		{name: "route table associations", dirName: "route_assoc", wantErr: false},
 		{name: "NAT gateway", dirName: "aws_nat_gateway", wantErr: false},
 		{name: "Internet Gateway", dirName: "internet_gateway", wantErr: false},
+		{name: "Resources in child modules", dirName: "modules", wantErr: false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -142,3 +221,27 @@ func convert(got []resource.Resource) []interface{} {
 	}
 	return want
 }
+
+// droppingDeserializer returns fewer resources than values, sources could not be attributed to them
+type droppingDeserializer struct{}
+
+func (d droppingDeserializer) HandledType() resource.ResourceType {
+	return "aws_s3_bucket"
+}
+
+func (d droppingDeserializer) Deserialize(values []cty.Value) ([]resource.Resource, error) {
+	return []resource.Resource{&testresource.FakeResource{Id: "bucket", Type: "aws_s3_bucket"}}, nil
+}
+
+func TestDecode_ResourcesNotMatchingValues(t *testing.T) {
+	values := map[string][]stateValue{
+		"aws_s3_bucket": {
+			{value: cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("bucket")}), source: &resource.Source{}},
+			{value: cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("other")}), source: &resource.Source{}},
+		},
+	}
+
+	r := &TerraformStateReader{deserializers: []deserializer.CTYDeserializer{droppingDeserializer{}}}
+	_, err := r.decode(values)
+	assert.EqualError(t, err, "deserializer of aws_s3_bucket returned 1 resources for 2 values")
+}
@@ -62,7 +62,7 @@ func (m AwsDefaultInternetGateway) Execute(remoteResources, resourcesFromState *
 func isDefaultInternetGateway(internetGateway *aws.AwsInternetGateway, remoteResources *[]resource.Resource) bool {
 	for _, remoteResource := range *remoteResources {
 		if remoteResource.TerraformType() == aws.AwsDefaultVpcResourceType {
-			return *internetGateway.VpcId == remoteResource.TerraformId()
+			return internetGateway.VpcId != nil && *internetGateway.VpcId == remoteResource.TerraformId()
 		}
 	}
 	return false
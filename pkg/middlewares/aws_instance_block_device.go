@@ -33,6 +33,7 @@ func (a AwsInstanceBlockDeviceResourceMapper) Execute(remoteResources, resources
 					"instance": instance.TerraformId(),
 				}).Debug("Creating aws_ebs_volume from aws_instance.root_block_device")
 				ebsVolume := aws.AwsEbsVolume{
+					Metadata:           instance.Metadata,
 					AvailabilityZone:   instance.AvailabilityZone,
 					Encrypted:          rootBlock.Encrypted,
 					Id:                 *rootBlock.VolumeId,
@@ -54,6 +55,7 @@ func (a AwsInstanceBlockDeviceResourceMapper) Execute(remoteResources, resources
 					"instance": instance.TerraformId(),
 				}).Debug("Creating aws_ebs_volume from aws_instance.ebs_block_device")
 				ebsVolume := aws.AwsEbsVolume{
+					Metadata:           instance.Metadata,
 					AvailabilityZone:   instance.AvailabilityZone,
 					Encrypted:          blockDevice.Encrypted,
 					Id:                 *blockDevice.VolumeId,
@@ -32,13 +32,13 @@ func (a AwsInstanceEIP) hasEIP(instance *aws.AwsInstance, resources *[]resource.
 	for _, res := range *resources {
 		if res.TerraformType() == aws.AwsEipResourceType {
 			eip, _ := res.(*aws.AwsEip)
-			if *eip.Instance == instance.Id {
+			if eip.Instance != nil && *eip.Instance == instance.Id {
 				return true
 			}
 		}
 		if res.TerraformType() == aws.AwsEipAssociationResourceType {
 			eip, _ := res.(*aws.AwsEipAssociation)
-			if *eip.InstanceId == instance.Id {
+			if eip.InstanceId != nil && *eip.InstanceId == instance.Id {
 				return true
 			}
 		}
@@ -52,6 +52,7 @@ func (m *AwsRouteTableExpander) handleTable(table *aws.AwsRouteTable, results *[
 	}
 	for _, route := range *table.Route {
 		newRouteFromTable := &aws.AwsRoute{
+			Metadata:                 table.Metadata,
 			DestinationCidrBlock:     route.CidrBlock,
 			DestinationIpv6CidrBlock: route.Ipv6CidrBlock,
 			DestinationPrefixListId:  awssdk.String(""),
@@ -92,6 +93,7 @@ func (m *AwsRouteTableExpander) handleDefaultTable(table *aws.AwsDefaultRouteTab
 	}
 	for _, route := range *table.Route {
 		newRouteFromTable := &aws.AwsRoute{
+			Metadata:                 table.Metadata,
 			DestinationCidrBlock:     route.CidrBlock,
 			DestinationIpv6CidrBlock: route.Ipv6CidrBlock,
 			DestinationPrefixListId:  awssdk.String(""),
@@ -208,3 +208,49 @@ func TestAwsRouteTableExpander_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestAwsRouteTableExpander_ExecuteKeepSource(t *testing.T) {
+	source := &resource.Source{
+		State:   "tfstate://terraform.tfstate",
+		Address: "module.vpc.aws_route_table.main",
+		Module:  "module.vpc",
+	}
+	input := []resource.Resource{
+		&aws.AwsRouteTable{
+			Metadata: resource.Metadata{Source: source},
+			Id:       "table_from_state",
+			Route: &[]struct {
+				CidrBlock              *string `cty:"cidr_block"`
+				EgressOnlyGatewayId    *string `cty:"egress_only_gateway_id"`
+				GatewayId              *string `cty:"gateway_id"`
+				InstanceId             *string `cty:"instance_id"`
+				Ipv6CidrBlock          *string `cty:"ipv6_cidr_block"`
+				LocalGatewayId         *string `cty:"local_gateway_id"`
+				NatGatewayId           *string `cty:"nat_gateway_id"`
+				NetworkInterfaceId     *string `cty:"network_interface_id"`
+				TransitGatewayId       *string `cty:"transit_gateway_id"`
+				VpcEndpointId          *string `cty:"vpc_endpoint_id"`
+				VpcPeeringConnectionId *string `cty:"vpc_peering_connection_id"`
+			}{
+				{
+					CidrBlock: awssdk.String("0.0.0.0/0"),
+					GatewayId: awssdk.String("igw-07b7844a8fd17a638"),
+				},
+			},
+		},
+	}
+
+	m := NewAwsRouteTableExpander()
+	if err := m.Execute(nil, &input); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(input) != 2 {
+		t.Fatalf("expected 2 resources, got %d", len(input))
+	}
+	for _, res := range input {
+		if got := resource.GetSource(res); got != source {
+			t.Errorf("%s: got source %+v, want %+v", res.TerraformType(), got, source)
+		}
+	}
+}
@@ -62,5 +62,5 @@ func (m Route53DefaultZoneRecordSanitizer) Execute(remoteResources, resourcesFro
 
 // Return true if the record is considered as default one added by aws
 func isDefaultRecord(record *aws.AwsRoute53Record) bool {
-	return *record.Type == "NS" || *record.Type == "SOA"
+	return record.Type != nil && (*record.Type == "NS" || *record.Type == "SOA")
 }
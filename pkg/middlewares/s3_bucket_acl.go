@@ -29,7 +29,7 @@ func (m S3BucketAcl) Execute(remoteResources, resourcesFromState *[]resource.Res
 		for _, remoteResource := range *remoteResources {
 			if resource.IsSameResource(remoteResource, decodedIacResource) {
 				decodedRemoteResource, _ := remoteResource.(*aws.AwsS3Bucket)
-				if decodedIacResource.Acl != nil && *decodedIacResource.Acl != "private" {
+				if decodedIacResource.Acl != nil && *decodedIacResource.Acl != "private" && decodedRemoteResource.Grant != nil {
 					logrus.WithFields(logrus.Fields{
 						"type": decodedRemoteResource.TerraformType(),
 						"id":   decodedRemoteResource.TerraformId(),
@@ -34,6 +34,7 @@ func (m VPCSecurityGroupRuleSanitizer) Execute(_, resourcesFromState *[]resource
 		if securityGroupRule.CidrBlocks != nil && len(*securityGroupRule.CidrBlocks) > 0 {
 			for _, ipRange := range *securityGroupRule.CidrBlocks {
 				rule := resourceaws.AwsSecurityGroupRule{
+					Metadata:        securityGroupRule.Metadata,
 					Type:            securityGroupRule.Type,
 					Description:     securityGroupRule.Description,
 					SecurityGroupId: securityGroupRule.SecurityGroupId,
@@ -55,6 +56,7 @@ func (m VPCSecurityGroupRuleSanitizer) Execute(_, resourcesFromState *[]resource
 		if securityGroupRule.Ipv6CidrBlocks != nil && len(*securityGroupRule.Ipv6CidrBlocks) > 0 {
 			for _, ipRange := range *securityGroupRule.Ipv6CidrBlocks {
 				rule := resourceaws.AwsSecurityGroupRule{
+					Metadata:        securityGroupRule.Metadata,
 					Type:            securityGroupRule.Type,
 					Description:     securityGroupRule.Description,
 					SecurityGroupId: securityGroupRule.SecurityGroupId,
@@ -76,6 +78,7 @@ func (m VPCSecurityGroupRuleSanitizer) Execute(_, resourcesFromState *[]resource
 		if securityGroupRule.PrefixListIds != nil && len(*securityGroupRule.PrefixListIds) > 0 {
 			for _, listId := range *securityGroupRule.PrefixListIds {
 				rule := resourceaws.AwsSecurityGroupRule{
+					Metadata:        securityGroupRule.Metadata,
 					Type:            securityGroupRule.Type,
 					Description:     securityGroupRule.Description,
 					SecurityGroupId: securityGroupRule.SecurityGroupId,
@@ -97,6 +100,7 @@ func (m VPCSecurityGroupRuleSanitizer) Execute(_, resourcesFromState *[]resource
 		if (securityGroupRule.Self != nil && *securityGroupRule.Self) ||
 			(securityGroupRule.SourceSecurityGroupId != nil && *securityGroupRule.SourceSecurityGroupId != "") {
 			rule := resourceaws.AwsSecurityGroupRule{
+				Metadata:              securityGroupRule.Metadata,
 				Type:                  securityGroupRule.Type,
 				Description:           securityGroupRule.Description,
 				SecurityGroupId:       securityGroupRule.SecurityGroupId,
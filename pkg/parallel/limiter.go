@@ -0,0 +1,92 @@
+package parallel
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// limiter bounds the number of routines running at once. The limit is halved when the work is throttled
+// and raised back by one each time as many routines as the limit have succeeded (additive increase, multiplicative decrease).
+type limiter struct {
+	lock      sync.Mutex
+	max       int64
+	limit     int64
+	running   int64
+	successes int64
+	released  chan struct{} // closed and replaced each time a routine may start
+}
+
+func newLimiter(max int64) *limiter {
+	if max < 1 {
+		max = 1
+	}
+	return &limiter{
+		max:      max,
+		limit:    max,
+		released: make(chan struct{}),
+	}
+}
+
+// acquire blocks until a routine may start or the context is done
+func (l *limiter) acquire(ctx context.Context) error {
+	for {
+		l.lock.Lock()
+		if l.running < l.limit {
+			l.running++
+			l.lock.Unlock()
+			return nil
+		}
+		released := l.released
+		l.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+func (l *limiter) release() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.running--
+	l.notify()
+}
+
+func (l *limiter) succeeded() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.limit >= l.max {
+		return
+	}
+	l.successes++
+	if l.successes >= l.limit {
+		l.limit++
+		l.successes = 0
+		l.notify()
+		logrus.WithFields(logrus.Fields{
+			"limit": l.limit,
+		}).Debug("Raising parallelism after throttling")
+	}
+}
+
+func (l *limiter) throttled() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.successes = 0
+	if l.limit == 1 {
+		return
+	}
+	l.limit /= 2
+	logrus.WithFields(logrus.Fields{
+		"limit": l.limit,
+	}).Debug("Lowering parallelism because of throttling")
+}
+
+func (l *limiter) notify() {
+	close(l.released)
+	l.released = make(chan struct{})
+}
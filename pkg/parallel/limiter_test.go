@@ -0,0 +1,61 @@
+package parallel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Throttled(t *testing.T) {
+	assert := assert.New(t)
+
+	l := newLimiter(8)
+	l.throttled()
+	assert.Equal(int64(4), l.limit)
+	l.throttled()
+	l.throttled()
+	l.throttled()
+	assert.Equal(int64(1), l.limit)
+
+	// Limit is raised by one once as many routines as the limit have succeeded
+	l.succeeded()
+	assert.Equal(int64(2), l.limit)
+	l.succeeded()
+	assert.Equal(int64(2), l.limit)
+	l.succeeded()
+	assert.Equal(int64(3), l.limit)
+
+	for i := 0; i < 100; i++ {
+		l.succeeded()
+	}
+	assert.Equal(int64(8), l.limit)
+}
+
+func TestLimiter_Acquire(t *testing.T) {
+	assert := assert.New(t)
+
+	l := newLimiter(2)
+	l.throttled()
+	assert.Nil(l.acquire(context.TODO()))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(context.DeadlineExceeded, l.acquire(ctx))
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- l.acquire(context.TODO())
+	}()
+	l.release()
+	assert.Nil(<-acquired)
+}
+
+func TestParallelRunner_Throttled(t *testing.T) {
+	runner := NewParallelRunner(context.TODO(), 10)
+	subRunner := runner.SubRunner()
+
+	subRunner.Throttled()
+	assert.Equal(t, int64(5), runner.limiter.limit)
+}
@@ -9,12 +9,13 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"go.uber.org/atomic"
-
-	"golang.org/x/sync/semaphore"
 )
 
+// DefaultMaxRun is the number of routines running at once by default
+const DefaultMaxRun = 10
+
 type ParallelRunner struct {
-	sem     *semaphore.Weighted
+	limiter *limiter
 	wg      *sync.WaitGroup
 	ctx     context.Context
 	cancel  context.CancelFunc
@@ -22,12 +23,14 @@ type ParallelRunner struct {
 	err     error
 	hasErr  *atomic.Bool
 	waiting *atomic.Bool
+	name    *atomic.String
+	parent  *ParallelRunner
 }
 
 func NewParallelRunner(ctx context.Context, maxRun int64) *ParallelRunner {
 	ctx, cancelFunc := context.WithCancel(ctx)
 	return &ParallelRunner{
-		sem:     semaphore.NewWeighted(maxRun),
+		limiter: newLimiter(maxRun),
 		wg:      &sync.WaitGroup{},
 		ctx:     ctx,
 		cancel:  cancelFunc,
@@ -35,13 +38,14 @@ func NewParallelRunner(ctx context.Context, maxRun int64) *ParallelRunner {
 		err:     nil,
 		hasErr:  atomic.NewBool(false),
 		waiting: atomic.NewBool(false),
+		name:    atomic.NewString(""),
 	}
 }
 
 func (p *ParallelRunner) SubRunner() *ParallelRunner {
 	ctx, cancelFunc := context.WithCancel(p.ctx)
 	return &ParallelRunner{
-		sem:     p.sem,
+		limiter: p.limiter,
 		wg:      &sync.WaitGroup{},
 		ctx:     ctx,
 		cancel:  cancelFunc,
@@ -49,9 +53,24 @@ func (p *ParallelRunner) SubRunner() *ParallelRunner {
 		err:     nil,
 		hasErr:  atomic.NewBool(false),
 		waiting: atomic.NewBool(false),
+		name:    atomic.NewString(""),
+		parent:  p,
 	}
 }
 
+// SetName names the work of the runner, e.g. the supplier it runs reads for
+func (p *ParallelRunner) SetName(name string) {
+	p.name.Store(name)
+}
+
+// Name returns the name of the runner, sub runners are named after their parent unless named otherwise
+func (p *ParallelRunner) Name() string {
+	if name := p.name.Load(); name != "" || p.parent == nil {
+		return name
+	}
+	return p.parent.Name()
+}
+
 func (p *ParallelRunner) Read() chan interface{} {
 	p.wait()
 	return p.resChan
@@ -77,9 +96,9 @@ func (p *ParallelRunner) wait() {
 func (p *ParallelRunner) Run(runnable func() (interface{}, error)) {
 	p.wg.Add(1)
 	go func() {
-		if err := p.sem.Acquire(p.ctx, 1); err == nil {
-			// only release if sem was acquired
-			defer p.sem.Release(1)
+		if err := p.limiter.acquire(p.ctx); err == nil {
+			// only release if limiter was acquired
+			defer p.limiter.release()
 		}
 		defer p.wg.Done()
 		// Prevent new routines executions if we already got an error from another routine
@@ -97,11 +116,19 @@ func (p *ParallelRunner) Run(runnable func() (interface{}, error)) {
 		res, err := runnable()
 		if err != nil {
 			p.Stop(err)
+		} else {
+			p.limiter.succeeded()
 		}
 		p.resChan <- res
 	}()
 }
 
+// Throttled lowers the number of routines running at once, it is raised back as routines succeed.
+// It is called by routines whose work has been rate limited, the limit is shared with sub runners.
+func (p *ParallelRunner) Throttled() {
+	p.limiter.throttled()
+}
+
 func (p *ParallelRunner) Stop(err error) {
 	if !p.hasErr.Swap(true) {
 		logrus.Debug("Stopping ParallelRunner")
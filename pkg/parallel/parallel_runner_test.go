@@ -72,3 +72,16 @@ Loop:
 	assert.Equal(err, runner.Err())
 	assert.Less(val, 100)
 }
+
+func TestParallelRunner_Name(t *testing.T) {
+	assert := assert.New(t)
+
+	runner := NewParallelRunner(context.TODO(), 10)
+	supplierRunner := runner.SubRunner()
+	readerRunner := supplierRunner.SubRunner()
+	supplierRunner.SetName("aws_vpc in eu-west-3")
+
+	assert.Equal("", runner.Name())
+	assert.Equal("aws_vpc in eu-west-3", supplierRunner.Name())
+	assert.Equal("aws_vpc in eu-west-3", readerRunner.Name())
+}
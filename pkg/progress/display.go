@@ -0,0 +1,150 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+// Display prints the progress of a scan, it is refreshed periodically so it is meant for terminals only
+type Display struct {
+	lock      sync.Mutex
+	out       io.Writer
+	suppliers map[string]*supplierProgress
+	order     []string // Suppliers in the order they started
+	printed   int      // Lines printed by the last refresh, erased by the next one
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+}
+
+type supplierProgress struct {
+	state  EventType
+	queued int
+	read   int
+	found  int
+}
+
+func NewDisplay(out io.Writer) *Display {
+	return &Display{
+		out:       out,
+		suppliers: make(map[string]*supplierProgress),
+		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+}
+
+// IsTerminal returns true when progress can be displayed on the given file
+func IsTerminal(file *os.File) bool {
+	return isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd())
+}
+
+func (d *Display) Report(event Event) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	supplier, exists := d.suppliers[event.Supplier]
+	if !exists {
+		supplier = &supplierProgress{state: SupplierListing}
+		d.suppliers[event.Supplier] = supplier
+		d.order = append(d.order, event.Supplier)
+	}
+
+	switch event.Type {
+	case SupplierListing, SupplierFailed:
+		supplier.state = event.Type
+	case SupplierDone:
+		supplier.state = event.Type
+		supplier.found = event.Count
+	case ResourceQueued:
+		supplier.queued++
+	case ResourceRead:
+		supplier.read++
+	}
+}
+
+// Start refreshes the display until Stop is called
+func (d *Display) Start(interval time.Duration) {
+	go func() {
+		defer close(d.doneCh)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				d.refresh()
+			case <-d.stopCh:
+				d.erase()
+				return
+			}
+		}
+	}()
+}
+
+// Stop erases the display, it is not refreshed anymore
+func (d *Display) Stop() {
+	close(d.stopCh)
+	<-d.doneCh
+}
+
+func (d *Display) refresh() {
+	lines := d.lines()
+	d.erase()
+	for _, line := range lines {
+		fmt.Fprintln(d.out, line)
+	}
+	d.printed = len(lines)
+}
+
+func (d *Display) erase() {
+	if d.printed > 0 {
+		// Move the cursor up to the first line printed and clear the screen from there
+		fmt.Fprintf(d.out, "\033[%dA\033[J", d.printed)
+		d.printed = 0
+	}
+}
+
+// lines returns a summary of the scan followed by the state of suppliers running or failed
+func (d *Display) lines() []string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	var done, failed, running, found, queued, read int
+	suppliers := make([]string, 0)
+	for _, name := range d.order {
+		supplier := d.suppliers[name]
+		queued += supplier.queued
+		read += supplier.read
+		switch supplier.state {
+		case SupplierDone:
+			done++
+			found += supplier.found
+			continue
+		case SupplierFailed:
+			failed++
+		default:
+			running++
+		}
+		// Reads of runners without supplier are only counted
+		if name == "" {
+			continue
+		}
+		suppliers = append(suppliers, fmt.Sprintf("  - %s: %s", name, supplier))
+	}
+
+	summary := fmt.Sprintf(
+		"Scanning: %d supplier(s) running, %d done, %d failed - %d resource(s) found, %d/%d read",
+		running, done, failed, found, read, queued,
+	)
+	return append([]string{summary}, suppliers...)
+}
+
+func (s supplierProgress) String() string {
+	if s.state == SupplierListing && s.queued > 0 {
+		return fmt.Sprintf("reading %d/%d resources", s.read, s.queued)
+	}
+	return string(s.state)
+}
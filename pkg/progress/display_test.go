@@ -0,0 +1,55 @@
+package progress
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplay_Lines(t *testing.T) {
+	d := NewDisplay(&bytes.Buffer{})
+
+	events := []Event{
+		{Type: SupplierListing, Supplier: "aws_s3_bucket"},
+		{Type: SupplierListing, Supplier: "aws_instance in eu-west-3"},
+		{Type: SupplierListing, Supplier: "aws_iam_user"},
+		{Type: SupplierListing, Supplier: "aws_vpc in eu-west-3"},
+		{Type: ResourceQueued, Supplier: "aws_s3_bucket"},
+		{Type: ResourceQueued, Supplier: "aws_s3_bucket"},
+		{Type: ResourceRead, Supplier: "aws_s3_bucket"},
+		{Type: ResourceQueued, Supplier: "aws_instance in eu-west-3"},
+		{Type: ResourceRead, Supplier: "aws_instance in eu-west-3"},
+		{Type: SupplierDone, Supplier: "aws_instance in eu-west-3", Count: 1},
+		{Type: SupplierFailed, Supplier: "aws_iam_user", Err: errors.New("AccessDenied")},
+	}
+	for _, event := range events {
+		d.Report(event)
+	}
+
+	assert.Equal(t, []string{
+		"Scanning: 2 supplier(s) running, 1 done, 1 failed - 1 resource(s) found, 2/3 read",
+		"  - aws_s3_bucket: reading 1/2 resources",
+		"  - aws_iam_user: failed",
+		"  - aws_vpc in eu-west-3: listing",
+	}, d.lines())
+}
+
+func TestDisplay_Refresh(t *testing.T) {
+	out := &bytes.Buffer{}
+	d := NewDisplay(out)
+	d.Report(Event{Type: SupplierListing, Supplier: "aws_s3_bucket"})
+
+	d.refresh()
+	assert.Equal(t, "Scanning: 1 supplier(s) running, 0 done, 0 failed - 0 resource(s) found, 0/0 read\n  - aws_s3_bucket: listing\n", out.String())
+
+	out.Reset()
+	d.Report(Event{Type: SupplierDone, Supplier: "aws_s3_bucket", Count: 3})
+	d.refresh()
+	assert.Equal(t, "\033[2A\033[JScanning: 0 supplier(s) running, 1 done, 0 failed - 3 resource(s) found, 0/0 read\n", out.String())
+
+	out.Reset()
+	d.erase()
+	assert.Equal(t, "\033[1A\033[J", out.String())
+}
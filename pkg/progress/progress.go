@@ -0,0 +1,59 @@
+package progress
+
+import "sync"
+
+type EventType string
+
+const (
+	SupplierListing EventType = "listing" // A supplier started listing resources on the cloud provider
+	SupplierDone    EventType = "done"    // A supplier returned its resources
+	SupplierFailed  EventType = "failed"  // A supplier was unable to list its resources
+	ResourceQueued  EventType = "queued"  // A resource has been listed and is waiting to be read
+	ResourceRead    EventType = "read"    // A resource has been read, successfully or not
+)
+
+// Event reports the progress of a scan
+type Event struct {
+	Type     EventType
+	Supplier string // Name of the supplier, e.g. aws_instance in eu-west-3
+	Count    int    // Number of resources returned by a supplier when done
+	Err      error  // Error of a failed supplier
+}
+
+// Reporter receives progress events, it is called concurrently by scanning routines and must not block
+type Reporter interface {
+	Report(Event)
+}
+
+var (
+	lock      sync.RWMutex
+	reporters []Reporter
+)
+
+// AddReporter registers a reporter receiving every event of the scan
+func AddReporter(reporter Reporter) {
+	lock.Lock()
+	defer lock.Unlock()
+	reporters = append(reporters, reporter)
+}
+
+// RemoveReporter stops sending events to a reporter
+func RemoveReporter(reporter Reporter) {
+	lock.Lock()
+	defer lock.Unlock()
+	for i, r := range reporters {
+		if r == reporter {
+			reporters = append(reporters[:i], reporters[i+1:]...)
+			return
+		}
+	}
+}
+
+// Report sends an event to every registered reporter
+func Report(event Event) {
+	lock.RLock()
+	defer lock.RUnlock()
+	for _, reporter := range reporters {
+		reporter.Report(event)
+	}
+}
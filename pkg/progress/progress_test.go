@@ -0,0 +1,25 @@
+package progress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeReporter struct {
+	events []Event
+}
+
+func (r *fakeReporter) Report(event Event) {
+	r.events = append(r.events, event)
+}
+
+func TestReport(t *testing.T) {
+	reporter := &fakeReporter{}
+	AddReporter(reporter)
+	Report(Event{Type: SupplierListing, Supplier: "aws_s3_bucket"})
+	RemoveReporter(reporter)
+	Report(Event{Type: SupplierDone, Supplier: "aws_s3_bucket"})
+
+	assert.Equal(t, []Event{{Type: SupplierListing, Supplier: "aws_s3_bucket"}}, reporter.events)
+}
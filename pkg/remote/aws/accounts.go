@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+// AccountSupplier records the account resources of a supplier have been found in
+type AccountSupplier struct {
+	supplier resource.Supplier
+	account  string
+}
+
+func NewAccountSupplier(supplier resource.Supplier, account string) *AccountSupplier {
+	return &AccountSupplier{supplier, account}
+}
+
+func (s AccountSupplier) String() string {
+	return fmt.Sprintf("%s of account %s", s.supplier, s.account)
+}
+
+func (s AccountSupplier) Resources() ([]resource.Resource, error) {
+	resources, err := s.supplier.Resources()
+	if err != nil {
+		var supplierErr *resource.SupplierError
+		if errors.As(err, &supplierErr) {
+			supplierErr.Account = s.account
+		}
+		return nil, err
+	}
+	for _, res := range resources {
+		if meta := resource.GetMetadata(res); meta != nil {
+			meta.Account = s.account
+		}
+	}
+	return resources, nil
+}
+
+func getAccountID(client stsiface.STSAPI) (string, error) {
+	identity, err := client.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(identity.Account), nil
+}
+
+// checkAccountID refuses accounts that are not in the allow-list or are in the deny-list
+func checkAccountID(account string, config awsConfig) error {
+	for _, forbidden := range config.ForbiddenAccountIds {
+		if account == forbidden {
+			return fmt.Errorf("AWS account ID not allowed: %s", account)
+		}
+	}
+	if len(config.AllowedAccountIds) == 0 {
+		return nil
+	}
+	for _, allowed := range config.AllowedAccountIds {
+		if account == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("AWS account ID not allowed: %s", account)
+}
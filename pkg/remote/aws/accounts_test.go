@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	testresource "github.com/cloudskiff/driftctl/test/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSTS struct {
+	stsiface.STSAPI
+	identity *sts.GetCallerIdentityOutput
+	err      error
+}
+
+func (c fakeSTS) GetCallerIdentity(*sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+	return c.identity, c.err
+}
+
+func TestAccountSupplier_Resources(t *testing.T) {
+	supplier := NewAccountSupplier(fakeSupplier{
+		resources: []resource.Resource{
+			&testresource.FakeResource{Id: "foo"},
+			&testresource.FakeResource{Id: "bar"},
+		},
+	}, "123456789012")
+
+	got, err := supplier.Resources()
+	assert.Nil(t, err)
+	assert.Len(t, got, 2)
+	for _, res := range got {
+		assert.Equal(t, "123456789012", resource.GetMetadata(res).Account)
+	}
+
+	_, err = NewAccountSupplier(fakeSupplier{err: errors.New("error")}, "123456789012").Resources()
+	assert.EqualError(t, err, "error")
+
+	_, err = NewAccountSupplier(resource.NewTypedSupplier(fakeSupplier{err: errors.New("error")}, "FakeResource"), "123456789012").Resources()
+	var supplierErr *resource.SupplierError
+	assert.True(t, errors.As(err, &supplierErr))
+	assert.Equal(t, "123456789012", supplierErr.Account)
+	assert.Equal(t, []string{"FakeResource"}, supplierErr.ResourceTypes)
+}
+
+func TestGetAccountID(t *testing.T) {
+	got, err := getAccountID(fakeSTS{identity: &sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}})
+	assert.Nil(t, err)
+	assert.Equal(t, "123456789012", got)
+
+	_, err = getAccountID(fakeSTS{err: errors.New("AccessDenied")})
+	assert.EqualError(t, err, "AccessDenied")
+}
+
+func TestCheckAccountID(t *testing.T) {
+	tests := []struct {
+		name    string
+		account string
+		config  awsConfig
+		wantErr string
+	}{
+		{
+			name:    "no restriction",
+			account: "111111111111",
+			config:  awsConfig{},
+		},
+		{
+			name:    "allowed account",
+			account: "111111111111",
+			config:  awsConfig{AllowedAccountIds: []string{"222222222222", "111111111111"}},
+		},
+		{
+			name:    "account not in allow-list",
+			account: "333333333333",
+			config:  awsConfig{AllowedAccountIds: []string{"222222222222", "111111111111"}},
+			wantErr: "AWS account ID not allowed: 333333333333",
+		},
+		{
+			name:    "account not forbidden",
+			account: "111111111111",
+			config:  awsConfig{ForbiddenAccountIds: []string{"222222222222"}},
+		},
+		{
+			name:    "forbidden account",
+			account: "222222222222",
+			config:  awsConfig{ForbiddenAccountIds: []string{"222222222222"}},
+			wantErr: "AWS account ID not allowed: 222222222222",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkAccountID(tt.account, tt.config)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
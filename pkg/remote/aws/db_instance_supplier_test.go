@@ -84,7 +84,7 @@ func TestDBInstanceSupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewDBInstanceSupplier(provider.Runner(), rds.New(provider.session)))
+			resource.AddSupplier(NewDBInstanceSupplier(provider, provider.Runner(), rds.New(provider.session)))
 		}
 
 		t.Run(tt.test, func(t *testing.T) {
@@ -22,8 +22,8 @@ type EC2AmiSupplier struct {
 	runner       *terraform.ParallelResourceReader
 }
 
-func NewEC2AmiSupplier(runner *parallel.ParallelRunner, client ec2iface.EC2API) *EC2AmiSupplier {
-	return &EC2AmiSupplier{terraform.Provider(terraform.AWS), awsdeserializer.NewEC2AmiDeserializer(), client, terraform.NewParallelResourceReader(runner)}
+func NewEC2AmiSupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client ec2iface.EC2API) *EC2AmiSupplier {
+	return &EC2AmiSupplier{reader, awsdeserializer.NewEC2AmiDeserializer(), client, terraform.NewParallelResourceReader(runner)}
 }
 
 func (s EC2AmiSupplier) Resources() ([]resource.Resource, error) {
@@ -46,7 +46,7 @@ func TestEC2AmiSupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewEC2AmiSupplier(provider.Runner(), ec2.New(provider.session)))
+			resource.AddSupplier(NewEC2AmiSupplier(provider, provider.Runner(), ec2.New(provider.session)))
 		}
 
 		t.Run(tt.test, func(t *testing.T) {
@@ -22,8 +22,8 @@ type EC2EbsVolumeSupplier struct {
 	runner       *terraform.ParallelResourceReader
 }
 
-func NewEC2EbsVolumeSupplier(runner *parallel.ParallelRunner, client ec2iface.EC2API) *EC2EbsVolumeSupplier {
-	return &EC2EbsVolumeSupplier{terraform.Provider(terraform.AWS), awsdeserializer.NewEC2EbsVolumeDeserializer(), client, terraform.NewParallelResourceReader(runner)}
+func NewEC2EbsVolumeSupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client ec2iface.EC2API) *EC2EbsVolumeSupplier {
+	return &EC2EbsVolumeSupplier{reader, awsdeserializer.NewEC2EbsVolumeDeserializer(), client, terraform.NewParallelResourceReader(runner)}
 }
 
 func (s EC2EbsVolumeSupplier) Resources() ([]resource.Resource, error) {
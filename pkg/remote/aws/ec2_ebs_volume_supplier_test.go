@@ -73,7 +73,7 @@ func TestEC2EbsVolumeSupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewEC2EbsVolumeSupplier(provider.Runner(), ec2.New(provider.session)))
+			resource.AddSupplier(NewEC2EbsVolumeSupplier(provider, provider.Runner(), ec2.New(provider.session)))
 		}
 
 		t.Run(tt.test, func(t *testing.T) {
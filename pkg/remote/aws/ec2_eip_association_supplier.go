@@ -9,6 +9,7 @@ import (
 	"github.com/cloudskiff/driftctl/pkg/terraform"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
@@ -21,20 +22,21 @@ type EC2EipAssociationSupplier struct {
 	runner       *terraform.ParallelResourceReader
 }
 
-func NewEC2EipAssociationSupplier(runner *parallel.ParallelRunner, client ec2iface.EC2API) *EC2EipAssociationSupplier {
-	return &EC2EipAssociationSupplier{terraform.Provider(terraform.AWS), awsdeserializer.NewEC2EipAssociationDeserializer(), client, terraform.NewParallelResourceReader(runner)}
+func NewEC2EipAssociationSupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client ec2iface.EC2API) *EC2EipAssociationSupplier {
+	return &EC2EipAssociationSupplier{reader, awsdeserializer.NewEC2EipAssociationDeserializer(), client, terraform.NewParallelResourceReader(runner)}
 }
 
 func (s EC2EipAssociationSupplier) Resources() ([]resource.Resource, error) {
-	associationIds, err := listAddressesAssociationIds(s.client)
+	addresses, err := listAddressesAssociated(s.client)
 	if err != nil {
 		return nil, err
 	}
 	results := make([]cty.Value, 0)
-	if len(associationIds) > 0 {
-		for _, assocId := range associationIds {
+	if len(addresses) > 0 {
+		for _, address := range addresses {
+			addr := *address
 			s.runner.Run(func() (cty.Value, error) {
-				return s.readEIPAssociation(assocId)
+				return s.readEIPAssociation(addr)
 			})
 		}
 		results, err = s.runner.Wait()
@@ -45,10 +47,20 @@ func (s EC2EipAssociationSupplier) Resources() ([]resource.Resource, error) {
 	return s.deserializer.Deserialize(results)
 }
 
-func (s EC2EipAssociationSupplier) readEIPAssociation(assocId string) (cty.Value, error) {
+func (s EC2EipAssociationSupplier) readEIPAssociation(address ec2.Address) (cty.Value, error) {
+	assocId := aws.StringValue(address.AssociationId)
+	// Middlewares match associations with NAT gateways and instances, these are given for associations only listed in coverage mode
+	attributes := map[string]string{}
+	if address.AllocationId != nil {
+		attributes["allocation_id"] = *address.AllocationId
+	}
+	if address.InstanceId != nil {
+		attributes["instance_id"] = *address.InstanceId
+	}
 	resAssoc, err := s.reader.ReadResource(terraform.ReadResourceArgs{
-		Ty: resourceaws.AwsEipAssociationResourceType,
-		ID: assocId,
+		Ty:         resourceaws.AwsEipAssociationResourceType,
+		ID:         assocId,
+		Attributes: attributes,
 	})
 	if err != nil {
 		logrus.Warnf("Error reading eip association %s[%s]: %+v", assocId, resourceaws.AwsEipAssociationResourceType, err)
@@ -57,15 +69,15 @@ func (s EC2EipAssociationSupplier) readEIPAssociation(assocId string) (cty.Value
 	return *resAssoc, nil
 }
 
-func listAddressesAssociationIds(client ec2iface.EC2API) ([]string, error) {
-	results := make([]string, 0)
+func listAddressesAssociated(client ec2iface.EC2API) ([]*ec2.Address, error) {
+	results := make([]*ec2.Address, 0)
 	addresses, err := listAddresses(client)
 	if err != nil {
 		return nil, err
 	}
 	for _, address := range addresses {
 		if address.AssociationId != nil {
-			results = append(results, aws.StringValue(address.AssociationId))
+			results = append(results, address)
 		}
 	}
 	return results, nil
@@ -51,7 +51,7 @@ func TestEC2EipAssociationSupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewEC2EipAssociationSupplier(provider.Runner(), ec2.New(provider.session)))
+			resource.AddSupplier(NewEC2EipAssociationSupplier(provider, provider.Runner(), ec2.New(provider.session)))
 		}
 
 		t.Run(tt.test, func(t *testing.T) {
@@ -22,8 +22,8 @@ type EC2EipSupplier struct {
 	runner       *terraform.ParallelResourceReader
 }
 
-func NewEC2EipSupplier(runner *parallel.ParallelRunner, client ec2iface.EC2API) *EC2EipSupplier {
-	return &EC2EipSupplier{terraform.Provider(terraform.AWS), awsdeserializer.NewEC2EipDeserializer(), client, terraform.NewParallelResourceReader(runner)}
+func NewEC2EipSupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client ec2iface.EC2API) *EC2EipSupplier {
+	return &EC2EipSupplier{reader, awsdeserializer.NewEC2EipDeserializer(), client, terraform.NewParallelResourceReader(runner)}
 }
 
 func (s EC2EipSupplier) Resources() ([]resource.Resource, error) {
@@ -49,9 +49,15 @@ func (s EC2EipSupplier) Resources() ([]resource.Resource, error) {
 
 func (s EC2EipSupplier) readEIP(address ec2.Address) (cty.Value, error) {
 	id := aws.StringValue(address.AllocationId)
+	// The instance tells EIPs of instances apart, it is given for EIPs only listed in coverage mode
+	attributes := map[string]string{}
+	if address.InstanceId != nil {
+		attributes["instance"] = *address.InstanceId
+	}
 	resAddress, err := s.reader.ReadResource(terraform.ReadResourceArgs{
-		Ty: resourceaws.AwsEipResourceType,
-		ID: id,
+		Ty:         resourceaws.AwsEipResourceType,
+		ID:         id,
+		Attributes: attributes,
 	})
 	if err != nil {
 		logrus.Warnf("Error reading eip %s[%s]: %+v", id, resourceaws.AwsEipResourceType, err)
@@ -54,7 +54,7 @@ func TestEC2EipSupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewEC2EipSupplier(provider.Runner(), ec2.New(provider.session)))
+			resource.AddSupplier(NewEC2EipSupplier(provider, provider.Runner(), ec2.New(provider.session)))
 		}
 
 		t.Run(tt.test, func(t *testing.T) {
@@ -21,8 +21,8 @@ type IamAccessKeySupplier struct {
 	runner       *terraform.ParallelResourceReader
 }
 
-func NewIamAccessKeySupplier(runner *parallel.ParallelRunner, client iamiface.IAMAPI) *IamAccessKeySupplier {
-	return &IamAccessKeySupplier{terraform.Provider(terraform.AWS), awsdeserializer.NewIamAccessKeyDeserializer(), client, terraform.NewParallelResourceReader(runner)}
+func NewIamAccessKeySupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client iamiface.IAMAPI) *IamAccessKeySupplier {
+	return &IamAccessKeySupplier{reader, awsdeserializer.NewIamAccessKeyDeserializer(), client, terraform.NewParallelResourceReader(runner)}
 }
 
 func (s IamAccessKeySupplier) Resources() ([]resource.Resource, error) {
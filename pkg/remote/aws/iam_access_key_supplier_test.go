@@ -117,7 +117,7 @@ func TestIamAccessKeySupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewIamAccessKeySupplier(provider.Runner(), iam.New(provider.session)))
+			resource.AddSupplier(NewIamAccessKeySupplier(provider, provider.Runner(), iam.New(provider.session)))
 		}
 
 		t.Run(c.test, func(tt *testing.T) {
@@ -22,8 +22,8 @@ type IamPolicySupplier struct {
 	runner       *terraform.ParallelResourceReader
 }
 
-func NewIamPolicySupplier(runner *parallel.ParallelRunner, client iamiface.IAMAPI) *IamPolicySupplier {
-	return &IamPolicySupplier{terraform.Provider(terraform.AWS), awsdeserializer.NewIamPolicyDeserializer(), client, terraform.NewParallelResourceReader(runner)}
+func NewIamPolicySupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client iamiface.IAMAPI) *IamPolicySupplier {
+	return &IamPolicySupplier{reader, awsdeserializer.NewIamPolicyDeserializer(), client, terraform.NewParallelResourceReader(runner)}
 }
 
 func (s IamPolicySupplier) Resources() ([]resource.Resource, error) {
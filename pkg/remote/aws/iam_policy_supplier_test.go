@@ -76,7 +76,7 @@ func TestIamPolicySupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewIamPolicySupplier(provider.Runner(), iam.New(provider.session)))
+			resource.AddSupplier(NewIamPolicySupplier(provider, provider.Runner(), iam.New(provider.session)))
 		}
 
 		t.Run(c.test, func(tt *testing.T) {
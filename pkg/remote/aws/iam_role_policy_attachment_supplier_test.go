@@ -142,7 +142,7 @@ func TestIamRolePolicyAttachmentSupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewIamRolePolicyAttachmentSupplier(provider.Runner(), iam.New(provider.session)))
+			resource.AddSupplier(NewIamRolePolicyAttachmentSupplier(provider, provider.Runner(), iam.New(provider.session)))
 		}
 
 		t.Run(c.test, func(tt *testing.T) {
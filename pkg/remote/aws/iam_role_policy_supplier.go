@@ -24,8 +24,8 @@ type IamRolePolicySupplier struct {
 	runner       *terraform.ParallelResourceReader
 }
 
-func NewIamRolePolicySupplier(runner *parallel.ParallelRunner, client iamiface.IAMAPI) *IamRolePolicySupplier {
-	return &IamRolePolicySupplier{terraform.Provider(terraform.AWS), awsdeserializer.NewIamRolePolicyDeserializer(), client, terraform.NewParallelResourceReader(runner)}
+func NewIamRolePolicySupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client iamiface.IAMAPI) *IamRolePolicySupplier {
+	return &IamRolePolicySupplier{reader, awsdeserializer.NewIamRolePolicyDeserializer(), client, terraform.NewParallelResourceReader(runner)}
 }
 
 func (s IamRolePolicySupplier) Resources() ([]resource.Resource, error) {
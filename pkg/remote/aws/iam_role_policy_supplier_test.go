@@ -133,7 +133,7 @@ func TestIamRolePolicySupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewIamRolePolicySupplier(provider.Runner(), iam.New(provider.session)))
+			resource.AddSupplier(NewIamRolePolicySupplier(provider, provider.Runner(), iam.New(provider.session)))
 		}
 
 		t.Run(c.test, func(tt *testing.T) {
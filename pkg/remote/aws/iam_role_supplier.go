@@ -30,8 +30,8 @@ type IamRoleSupplier struct {
 	runner       *terraform.ParallelResourceReader
 }
 
-func NewIamRoleSupplier(runner *parallel.ParallelRunner, client iamiface.IAMAPI) *IamRoleSupplier {
-	return &IamRoleSupplier{terraform.Provider(terraform.AWS), awsdeserializer.NewIamRoleDeserializer(), client, terraform.NewParallelResourceReader(runner)}
+func NewIamRoleSupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client iamiface.IAMAPI) *IamRoleSupplier {
+	return &IamRoleSupplier{reader, awsdeserializer.NewIamRoleDeserializer(), client, terraform.NewParallelResourceReader(runner)}
 }
 
 func awsIamRoleShouldBeIgnored(roleName string) bool {
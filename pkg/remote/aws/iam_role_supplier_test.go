@@ -95,7 +95,7 @@ func TestIamRoleSupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewIamRoleSupplier(provider.Runner(), iam.New(provider.session)))
+			resource.AddSupplier(NewIamRoleSupplier(provider, provider.Runner(), iam.New(provider.session)))
 		}
 
 		t.Run(c.test, func(tt *testing.T) {
@@ -21,8 +21,8 @@ type IamUserSupplier struct {
 	runner       *terraform.ParallelResourceReader
 }
 
-func NewIamUserSupplier(runner *parallel.ParallelRunner, client iamiface.IAMAPI) *IamUserSupplier {
-	return &IamUserSupplier{terraform.Provider(terraform.AWS), awsdeserializer.NewIamUserDeserializer(), client, terraform.NewParallelResourceReader(runner)}
+func NewIamUserSupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client iamiface.IAMAPI) *IamUserSupplier {
+	return &IamUserSupplier{reader, awsdeserializer.NewIamUserDeserializer(), client, terraform.NewParallelResourceReader(runner)}
 }
 
 func (s IamUserSupplier) Resources() ([]resource.Resource, error) {
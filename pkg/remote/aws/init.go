@@ -1,9 +1,19 @@
 package aws
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/parallel"
+	"github.com/cloudskiff/driftctl/pkg/remote/aws/readonly"
 	"github.com/cloudskiff/driftctl/pkg/resource"
+	resourceaws "github.com/cloudskiff/driftctl/pkg/resource/aws"
 	"github.com/cloudskiff/driftctl/pkg/terraform"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -14,55 +24,287 @@ import (
 
 const RemoteAWSTerraform = "aws+tf"
 
+// AWS services called while scanning, by driftctl and the terraform provider
+var scannedServices = []string{"ec2", "iam", "lambda", "rds", "route53", "s3", "sts"}
+
+// AllRegions can be used as a region to scan every region enabled for the account
+const AllRegions = "all"
+
+// Options holds settings of the aws+tf remote
+type Options struct {
+	Regions               []string                           // Regions to scan, defaults to the region of the AWS session
+	Profile               string                             // Named profile of the shared configuration to use
+	AssumeRoles           []string                           // ARN of roles to assume, one per account to scan
+	AssumeRoleExternalID  string                             // External ID used to assume roles
+	AssumeRoleSessionName string                             // Session name used to assume roles, defaults to driftctl
+	AssumeRoleReadOnly    bool                               // Restrict sessions of assumed roles to read-only actions with a session policy
+	AllowedAccountIds     []string                           // Accounts allowed to be scanned, every account is allowed when empty
+	ForbiddenAccountIds   []string                           // Accounts not allowed to be scanned
+	MaxRetries            int                                // Maximum number of retries of AWS API calls, defaults to 10
+	Endpoints             map[string]string                  // Custom endpoints by service (e.g. s3=http://localhost:4566)
+	S3ForcePathStyle      bool                               // Use path-style addressing of S3 buckets, required by most S3 emulators
+	Provider              terraform.ProviderInstallerOptions // Where the terraform provider is installed from
+	Types                 *filter.TypeFilter                 // Resource types to scan, every type is scanned when nil
+	ReadParallelism       int                                // Resources read at once by each terraform provider
+	ReadCache             terraform.ReadCacheOptions         // On-disk cache of resources read by terraform providers
+	ListOnly              bool                               // Resources are listed but not read, only their IDs are known
+}
+
+// config returns the configuration shared by the AWS session and the terraform provider
+func (o Options) config() awsConfig {
+	sessionName := o.AssumeRoleSessionName
+	if sessionName == "" {
+		sessionName = "driftctl"
+	}
+	policy := ""
+	if o.AssumeRoleReadOnly {
+		policy = readonly.SessionPolicy(scannedServices...)
+	}
+	return awsConfig{
+		Profile:               o.Profile,
+		MaxRetries:            o.MaxRetries,
+		AssumeRoleExternalID:  o.AssumeRoleExternalID,
+		AssumeRoleSessionName: sessionName,
+		AssumeRolePolicy:      policy,
+		AllowedAccountIds:     o.AllowedAccountIds,
+		ForbiddenAccountIds:   o.ForbiddenAccountIds,
+		Endpoints:             o.Endpoints,
+		S3ForcePathStyle:      o.S3ForcePathStyle,
+	}
+}
+
 /**
  * Initialize remote (configure credentials, launch tf providers and start gRPC clients)
  * Required to use Scanner
  */
-func Init(alerter *alerter.Alerter) error {
-	provider, err := NewTerraFormProvider()
-	if err != nil {
-		return err
+func Init(alerter *alerter.Alerter, options Options) error {
+	configs := []awsConfig{options.config()}
+	if len(options.AssumeRoles) > 0 {
+		configs = make([]awsConfig, 0, len(options.AssumeRoles))
+		for _, roleARN := range options.AssumeRoles {
+			config := options.config()
+			config.AssumeRoleARN = roleARN
+			configs = append(configs, config)
+		}
 	}
 
-	factory := AwsClientFactory{config: provider.session}
+	for i, config := range configs {
+		provider, err := newTerraFormProvider(config, options.Provider, options.ReadParallelism)
+		if err != nil {
+			return err
+		}
+
+		// Schemas are the same for every account, the first provider is used to read IaC
+		if i == 0 {
+			terraform.AddProvider(terraform.AWS, provider)
+			for key, alerts := range unsupportedAttributesAlerts(provider, options.Types) {
+				for _, alert := range alerts {
+					alerter.SendAlert(key, alert)
+				}
+			}
+		}
+
+		regions, err := resolveRegions(provider.session, options.Regions)
+		if err != nil {
+			return err
+		}
 
-	terraform.AddProvider(terraform.AWS, provider)
-
-	resource.AddSupplier(NewS3BucketSupplier(provider.Runner().SubRunner(), factory))
-	resource.AddSupplier(NewS3BucketAnalyticSupplier(provider.Runner().SubRunner(), factory))
-	resource.AddSupplier(NewS3BucketInventorySupplier(provider.Runner().SubRunner(), factory))
-	resource.AddSupplier(NewS3BucketMetricSupplier(provider.Runner().SubRunner(), factory))
-	resource.AddSupplier(NewS3BucketNotificationSupplier(provider.Runner().SubRunner(), factory))
-	resource.AddSupplier(NewS3BucketPolicySupplier(provider.Runner().SubRunner(), factory))
-	resource.AddSupplier(NewEC2EipSupplier(provider.Runner().SubRunner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewEC2EipAssociationSupplier(provider.Runner().SubRunner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewEC2EbsVolumeSupplier(provider.Runner().SubRunner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewEC2EbsSnapshotSupplier(provider.Runner().SubRunner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewRoute53ZoneSupplier(provider.Runner().SubRunner(), route53.New(provider.session)))
-	resource.AddSupplier(NewRoute53RecordSupplier(provider.Runner().SubRunner(), route53.New(provider.session)))
-	resource.AddSupplier(NewEC2InstanceSupplier(provider.Runner().SubRunner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewEC2AmiSupplier(provider.Runner().SubRunner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewEC2KeyPairSupplier(provider.Runner().SubRunner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewLambdaFunctionSupplier(provider.Runner().SubRunner(), lambda.New(provider.session)))
-	resource.AddSupplier(NewDBSubnetGroupSupplier(provider.Runner().SubRunner(), rds.New(provider.session)))
-	resource.AddSupplier(NewDBInstanceSupplier(provider.Runner().SubRunner(), rds.New(provider.session)))
-	resource.AddSupplier(NewVPCSecurityGroupSupplier(provider.Runner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewIamUserSupplier(provider.Runner().SubRunner(), iam.New(provider.session)))
-	resource.AddSupplier(NewIamUserPolicySupplier(provider.Runner().SubRunner(), iam.New(provider.session)))
-	resource.AddSupplier(NewIamUserPolicyAttachmentSupplier(provider.Runner().SubRunner(), iam.New(provider.session)))
-	resource.AddSupplier(NewIamAccessKeySupplier(provider.Runner().SubRunner(), iam.New(provider.session)))
-	resource.AddSupplier(NewIamRoleSupplier(provider.Runner().SubRunner(), iam.New(provider.session)))
-	resource.AddSupplier(NewIamPolicySupplier(provider.Runner().SubRunner(), iam.New(provider.session)))
-	resource.AddSupplier(NewIamRolePolicySupplier(provider.Runner().SubRunner(), iam.New(provider.session)))
-	resource.AddSupplier(NewIamRolePolicyAttachmentSupplier(provider.Runner().SubRunner(), iam.New(provider.session)))
-	resource.AddSupplier(NewVPCSecurityGroupRuleSupplier(provider.Runner().SubRunner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewVPCSupplier(provider.Runner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewSubnetSupplier(provider.Runner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewRouteTableSupplier(provider.Runner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewRouteSupplier(provider.Runner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewRouteTableAssociationSupplier(provider.Runner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewNatGatewaySupplier(provider.Runner(), ec2.New(provider.session)))
-	resource.AddSupplier(NewInternetGatewaySupplier(provider.Runner().SubRunner(), ec2.New(provider.session)))
+		account := ""
+		// Reads are cached by account, the same resource may exist in several accounts
+		if config.AssumeRoleARN != "" || len(config.AllowedAccountIds) > 0 || len(config.ForbiddenAccountIds) > 0 || options.ReadCache.Enabled() {
+			account, err = getAccountID(sts.New(provider.session))
+			if err != nil {
+				if config.AssumeRoleARN != "" {
+					return fmt.Errorf("Unable to assume role %s: %w", config.AssumeRoleARN, err)
+				}
+				return fmt.Errorf("Unable to retrieve AWS account ID: %w", err)
+			}
+			if err := checkAccountID(account, config); err != nil {
+				return err
+			}
+		}
+		provider.SetReadCache(options.ReadCache, account)
+		provider.SetListOnly(options.ListOnly)
+
+		// Resources are attributed to their account only when scanning several ones,
+		// progress is written on stderr as stdout may be redirected (e.g. gen-driftignore >> .driftignore)
+		if config.AssumeRoleARN != "" {
+			fmt.Fprintf(os.Stderr, "Scanning AWS account %s on region(s): %s\n", account, strings.Join(regions, ","))
+			addSuppliers(provider, account, regions, options.Types)
+		} else {
+			fmt.Fprintf(os.Stderr, "Scanning AWS on region(s): %s\n", strings.Join(regions, ","))
+			addSuppliers(provider, "", regions, options.Types)
+		}
+	}
 
 	return nil
 }
+
+func addSuppliers(provider *TerraformProvider, account string, regions []string, types *filter.TypeFilter) {
+	// Resource types are given with each supplier, a supplier failing only excludes its own types from the scan
+	// and a supplier is not started when none of its types is selected
+	// Each supplier is given its own runner, named after the supplier to report the progress of its reads
+	addSupplier := func(runner *parallel.ParallelRunner, supplier resource.Supplier, region string, resourceTypes ...string) {
+		if !isAnyTypeSelected(types, resourceTypes) {
+			return
+		}
+		supplier = resource.NewTypedSupplier(supplier, resourceTypes...)
+		if region != "" {
+			supplier = NewRegionalSupplier(supplier, region)
+		}
+		if account != "" {
+			supplier = NewAccountSupplier(supplier, account)
+		}
+		runner.SetName(fmt.Sprint(supplier))
+		resource.AddSupplier(supplier)
+	}
+
+	factory := AwsClientFactory{config: provider.session}
+	globalReader := provider.Reader()
+	var runner *parallel.ParallelRunner
+
+	// Listings used by several suppliers are done once per scan, e.g. hosted zones for zones and records
+	route53Client := newSharedRoute53(route53.New(provider.session))
+
+	// Global services are scanned once, S3 buckets are read in their own region
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewS3BucketSupplier(globalReader, runner, factory), "", resourceaws.AwsS3BucketResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewS3BucketAnalyticSupplier(globalReader, runner, factory), "", resourceaws.AwsS3BucketAnalyticsConfigurationResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewS3BucketInventorySupplier(globalReader, runner, factory), "", resourceaws.AwsS3BucketInventoryResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewS3BucketMetricSupplier(globalReader, runner, factory), "", resourceaws.AwsS3BucketMetricResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewS3BucketNotificationSupplier(globalReader, runner, factory), "", resourceaws.AwsS3BucketNotificationResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewS3BucketPolicySupplier(globalReader, runner, factory), "", resourceaws.AwsS3BucketPolicyResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewRoute53ZoneSupplier(globalReader, runner, route53Client), "", resourceaws.AwsRoute53ZoneResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewRoute53RecordSupplier(globalReader, runner, route53Client), "", resourceaws.AwsRoute53RecordResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewIamUserSupplier(globalReader, runner, iam.New(provider.session)), "", resourceaws.AwsIamUserResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewIamUserPolicySupplier(globalReader, runner, iam.New(provider.session)), "", resourceaws.AwsIamUserPolicyResourceType)
+	// Policy attachments of users and roles are merged into aws_iam_policy_attachment by middlewares
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewIamUserPolicyAttachmentSupplier(globalReader, runner, iam.New(provider.session)), "", resourceaws.AwsIamUserPolicyAttachmentResourceType, resourceaws.AwsIamPolicyAttachmentResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewIamAccessKeySupplier(globalReader, runner, iam.New(provider.session)), "", resourceaws.AwsIamAccessKeyResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewIamRoleSupplier(globalReader, runner, iam.New(provider.session)), "", resourceaws.AwsIamRoleResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewIamPolicySupplier(globalReader, runner, iam.New(provider.session)), "", resourceaws.AwsIamPolicyResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewIamRolePolicySupplier(globalReader, runner, iam.New(provider.session)), "", resourceaws.AwsIamRolePolicyResourceType)
+	runner = provider.Runner().SubRunner()
+	addSupplier(runner, NewIamRolePolicyAttachmentSupplier(globalReader, runner, iam.New(provider.session)), "", resourceaws.AwsIamRolePolicyAttachmentResourceType, resourceaws.AwsIamPolicyAttachmentResourceType)
+
+	for _, region := range regions {
+		sess := provider.session.Copy(&aws.Config{Region: aws.String(region)})
+		reader := provider.RegionalReader(region)
+		// Addresses, security groups and route tables are listed once per region
+		ec2Client := newSharedEC2(ec2.New(sess))
+
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewEC2EipSupplier(reader, runner, ec2Client), region, resourceaws.AwsEipResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewEC2EipAssociationSupplier(reader, runner, ec2Client), region, resourceaws.AwsEipAssociationResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewEC2EbsVolumeSupplier(reader, runner, ec2.New(sess)), region, resourceaws.AwsEbsVolumeResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewEC2EbsSnapshotSupplier(reader, runner, ec2.New(sess)), region, resourceaws.AwsEbsSnapshotResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewEC2InstanceSupplier(reader, runner, ec2.New(sess)), region, resourceaws.AwsInstanceResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewEC2AmiSupplier(reader, runner, ec2.New(sess)), region, resourceaws.AwsAmiResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewEC2KeyPairSupplier(reader, runner, ec2.New(sess)), region, resourceaws.AwsKeyPairResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewLambdaFunctionSupplier(reader, runner, lambda.New(sess)), region, resourceaws.AwsLambdaFunctionResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewDBSubnetGroupSupplier(reader, runner, rds.New(sess)), region, resourceaws.AwsDbSubnetGroupResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewDBInstanceSupplier(reader, runner, rds.New(sess)), region, resourceaws.AwsDbInstanceResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewVPCSecurityGroupSupplier(reader, runner, ec2Client), region, resourceaws.AwsSecurityGroupResourceType, resourceaws.AwsDefaultSecurityGroupResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewVPCSecurityGroupRuleSupplier(reader, runner, ec2Client), region, resourceaws.AwsSecurityGroupRuleResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewVPCSupplier(reader, runner, ec2.New(sess)), region, resourceaws.AwsVpcResourceType, resourceaws.AwsDefaultVpcResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewSubnetSupplier(reader, runner, ec2.New(sess)), region, resourceaws.AwsSubnetResourceType, resourceaws.AwsDefaultSubnetResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewRouteTableSupplier(reader, runner, ec2Client), region, resourceaws.AwsRouteTableResourceType, resourceaws.AwsDefaultRouteTableResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewRouteSupplier(reader, runner, ec2Client), region, resourceaws.AwsRouteResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewRouteTableAssociationSupplier(reader, runner, ec2Client), region, resourceaws.AwsRouteTableAssociationResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewNatGatewaySupplier(reader, runner, ec2.New(sess)), region, resourceaws.AwsNatGatewayResourceType)
+		runner = provider.Runner().SubRunner()
+		addSupplier(runner, NewInternetGatewaySupplier(reader, runner, ec2.New(sess)), region, resourceaws.AwsInternetGatewayResourceType)
+	}
+}
+
+// Middlewares classify resources of some types with resources of other types, e.g. routes of the default internet
+// gateway are found with the default VPC. These types are scanned along with the selected ones, the type filter
+// then leaves them out of the analysis
+var typeDependencies = map[string][]string{
+	resourceaws.AwsRouteResourceType:           {resourceaws.AwsInternetGatewayResourceType, resourceaws.AwsDefaultVpcResourceType},
+	resourceaws.AwsInternetGatewayResourceType: {resourceaws.AwsDefaultVpcResourceType},
+	resourceaws.AwsEipAssociationResourceType:  {resourceaws.AwsNatGatewayResourceType},
+	resourceaws.AwsInstanceResourceType:        {resourceaws.AwsEipResourceType, resourceaws.AwsEipAssociationResourceType},
+}
+
+// isAnyTypeSelected returns true when one of the types is selected, or is needed to classify a selected type
+func isAnyTypeSelected(types *filter.TypeFilter, resourceTypes []string) bool {
+	for _, ty := range resourceTypes {
+		if types.IsTypeSelected(ty) {
+			return true
+		}
+		for dependent, dependencies := range typeDependencies {
+			if !types.IsTypeSelected(dependent) {
+				continue
+			}
+			for _, dependency := range dependencies {
+				if dependency == ty {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// unsupportedAttributesAlerts warns about attributes of the provider that resources cannot hold,
+// these attributes are not compared when the provider is not the version resources have been generated from
+func unsupportedAttributesAlerts(provider *TerraformProvider, types *filter.TypeFilter) alerter.Alerts {
+	alerts := make(alerter.Alerts)
+	schemas := provider.Schema()
+	for _, res := range resourceaws.Resources() {
+		if !types.IsTypeSelected(res.TerraformType()) {
+			continue
+		}
+		schema, exists := schemas[res.TerraformType()]
+		if !exists {
+			continue
+		}
+		attributes := resource.UnsupportedAttributes(schema.Block.ImpliedType(), res)
+		if len(attributes) == 0 {
+			continue
+		}
+		alerts[res.TerraformType()] = []alerter.Alert{
+			{
+				Message: fmt.Sprintf(
+					"%s attributes of AWS provider %s are not compared: %s",
+					res.TerraformType(),
+					provider.Version(),
+					strings.Join(attributes, ", "),
+				),
+			},
+		}
+	}
+	return alerts
+}
@@ -0,0 +1,33 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	resourceaws "github.com/cloudskiff/driftctl/pkg/resource/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAnyTypeSelected(t *testing.T) {
+	types, err := filter.NewTypeFilter([]string{resourceaws.AwsRouteResourceType}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, isAnyTypeSelected(types, []string{resourceaws.AwsRouteResourceType}))
+	// Default internet gateway routes are found with the default VPC and its internet gateway
+	assert.True(t, isAnyTypeSelected(types, []string{resourceaws.AwsInternetGatewayResourceType}))
+	assert.True(t, isAnyTypeSelected(types, []string{resourceaws.AwsVpcResourceType, resourceaws.AwsDefaultVpcResourceType}))
+	assert.False(t, isAnyTypeSelected(types, []string{resourceaws.AwsNatGatewayResourceType}))
+	assert.False(t, isAnyTypeSelected(types, []string{resourceaws.AwsS3BucketResourceType}))
+
+	types, err = filter.NewTypeFilter([]string{resourceaws.AwsEipAssociationResourceType}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, isAnyTypeSelected(types, []string{resourceaws.AwsNatGatewayResourceType}))
+	assert.False(t, isAnyTypeSelected(types, []string{resourceaws.AwsInternetGatewayResourceType}))
+
+	// Every type is selected without filter
+	assert.True(t, isAnyTypeSelected(nil, []string{resourceaws.AwsNatGatewayResourceType}))
+}
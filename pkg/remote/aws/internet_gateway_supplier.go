@@ -20,9 +20,9 @@ type InternetGatewaySupplier struct {
 	runner       *terraform.ParallelResourceReader
 }
 
-func NewInternetGatewaySupplier(runner *parallel.ParallelRunner, client ec2iface.EC2API) *InternetGatewaySupplier {
+func NewInternetGatewaySupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client ec2iface.EC2API) *InternetGatewaySupplier {
 	return &InternetGatewaySupplier{
-		terraform.Provider(terraform.AWS),
+		reader,
 		awsdeserializer.NewInternetGatewayDeserializer(),
 		client,
 		terraform.NewParallelResourceReader(runner),
@@ -52,9 +52,15 @@ func (s InternetGatewaySupplier) Resources() ([]resource.Resource, error) {
 
 func (s InternetGatewaySupplier) readInternetGateway(internetGateway ec2.InternetGateway) (cty.Value, error) {
 	var Ty resource.ResourceType = aws.AwsInternetGatewayResourceType
+	// The VPC tells the default internet gateway apart, it is given for gateways only listed in coverage mode
+	attributes := map[string]string{}
+	if len(internetGateway.Attachments) > 0 && internetGateway.Attachments[0].VpcId != nil {
+		attributes["vpc_id"] = *internetGateway.Attachments[0].VpcId
+	}
 	val, err := s.reader.ReadResource(terraform.ReadResourceArgs{
-		Ty: Ty,
-		ID: *internetGateway.InternetGatewayId,
+		Ty:         Ty,
+		ID:         *internetGateway.InternetGatewayId,
+		Attributes: attributes,
 	})
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
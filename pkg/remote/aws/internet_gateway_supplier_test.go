@@ -71,7 +71,7 @@ func TestInternetGatewaySupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewInternetGatewaySupplier(provider.Runner(), ec2.New(provider.session)))
+			resource.AddSupplier(NewInternetGatewaySupplier(provider, provider.Runner(), ec2.New(provider.session)))
 		}
 
 		t.Run(c.test, func(tt *testing.T) {
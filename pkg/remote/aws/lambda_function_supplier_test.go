@@ -90,7 +90,7 @@ func TestLambdaFunctionSupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewLambdaFunctionSupplier(provider.Runner(), lambda.New(provider.session)))
+			resource.AddSupplier(NewLambdaFunctionSupplier(provider, provider.Runner(), lambda.New(provider.session)))
 		}
 
 		t.Run(tt.test, func(t *testing.T) {
@@ -20,9 +20,9 @@ type NatGatewaySupplier struct {
 	runner       *terraform.ParallelResourceReader
 }
 
-func NewNatGatewaySupplier(runner *parallel.ParallelRunner, client ec2iface.EC2API) *NatGatewaySupplier {
+func NewNatGatewaySupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client ec2iface.EC2API) *NatGatewaySupplier {
 	return &NatGatewaySupplier{
-		terraform.Provider(terraform.AWS),
+		reader,
 		awsdeserializer.NewNatGatewayDeserializer(),
 		client,
 		terraform.NewParallelResourceReader(runner.SubRunner()),
@@ -60,9 +60,15 @@ func (s NatGatewaySupplier) Resources() ([]resource.Resource, error) {
 
 func (s NatGatewaySupplier) readNatGateway(gateway ec2.NatGateway) (cty.Value, error) {
 	var Ty resource.ResourceType = aws.AwsNatGatewayResourceType
+	// The allocation tells EIP associations of the gateway apart, it is given for gateways only listed in coverage mode
+	attributes := map[string]string{}
+	if len(gateway.NatGatewayAddresses) > 0 && gateway.NatGatewayAddresses[0].AllocationId != nil {
+		attributes["allocation_id"] = *gateway.NatGatewayAddresses[0].AllocationId
+	}
 	val, err := s.reader.ReadResource(terraform.ReadResourceArgs{
-		ID: *gateway.NatGatewayId,
-		Ty: Ty,
+		ID:         *gateway.NatGatewayId,
+		Ty:         Ty,
+		Attributes: attributes,
 	})
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
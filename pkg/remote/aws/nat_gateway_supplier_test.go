@@ -67,7 +67,7 @@ func TestNatGatewaySupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewNatGatewaySupplier(provider.Runner(), ec2.New(provider.session)))
+			resource.AddSupplier(NewNatGatewaySupplier(provider, provider.Runner(), ec2.New(provider.session)))
 		}
 
 		t.Run(c.test, func(tt *testing.T) {
@@ -0,0 +1,103 @@
+package readonly
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// ErrCodeOperationNotAllowed is the code of errors returned for operations blocked by the read-only guard
+const ErrCodeOperationNotAllowed = "OperationNotAllowed"
+
+// HandlerName is the name of the request handler guarding sessions
+const HandlerName = "driftctl.ReadOnlyGuard"
+
+// Operations are allowed when their name starts with one of these prefixes
+var allowedPrefixes = []string{"Describe", "List", "Get", "Head"}
+
+// Credentials of assumed roles are retrieved from STS, these operations do not modify anything
+var allowedOperations = map[string]bool{
+	"sts:AssumeRole":                true,
+	"sts:AssumeRoleWithSAML":        true,
+	"sts:AssumeRoleWithWebIdentity": true,
+}
+
+// Handler rejects requests of operations that are not read-only before they are sent
+var Handler = request.NamedHandler{
+	Name: HandlerName,
+	Fn: func(r *request.Request) {
+		if r.Operation == nil {
+			return
+		}
+		if !IsAllowed(r.ClientInfo.ServiceName, r.Operation.Name) {
+			r.Error = awserr.New(
+				ErrCodeOperationNotAllowed,
+				fmt.Sprintf(
+					"%s:%s is blocked, driftctl only calls read-only operations (%s)",
+					r.ClientInfo.ServiceName,
+					r.Operation.Name,
+					strings.Join(allowedPatterns(), ", "),
+				),
+				nil,
+			)
+		}
+	},
+}
+
+// IsAllowed returns true when the operation of the service is on the read-only allow-list
+func IsAllowed(service, operation string) bool {
+	if allowedOperations[service+":"+operation] {
+		return true
+	}
+	for _, prefix := range allowedPrefixes {
+		if strings.HasPrefix(operation, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Guard installs the read-only guard on the session, clients created from the session afterwards are guarded
+func Guard(sess *session.Session) *session.Session {
+	// Validation is the first step of a request, blocked requests are never signed nor sent
+	sess.Handlers.Validate.PushFrontNamed(Handler)
+	return sess
+}
+
+// SessionPolicy returns an IAM policy allowing read-only actions of the given services,
+// it is given when assuming roles to restrict the session AWS side, including calls of the terraform provider
+func SessionPolicy(services ...string) string {
+	actions := make([]string, 0, len(services)*len(allowedPrefixes))
+	for _, service := range services {
+		for _, prefix := range allowedPrefixes {
+			// Head operations are authorized by Get and List actions
+			if prefix == "Head" {
+				continue
+			}
+			actions = append(actions, fmt.Sprintf("%s:%s*", service, prefix))
+		}
+	}
+	policy, _ := json.Marshal(map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []map[string]interface{}{
+			{
+				"Effect":   "Allow",
+				"Action":   actions,
+				"Resource": "*",
+			},
+		},
+	})
+	return string(policy)
+}
+
+func allowedPatterns() []string {
+	patterns := make([]string, 0, len(allowedPrefixes))
+	for _, prefix := range allowedPrefixes {
+		patterns = append(patterns, prefix+"*")
+	}
+	return patterns
+}
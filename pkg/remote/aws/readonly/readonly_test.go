@@ -0,0 +1,80 @@
+package readonly
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAllowed(t *testing.T) {
+	tests := []struct {
+		service   string
+		operation string
+		want      bool
+	}{
+		{service: "ec2", operation: "DescribeInstances", want: true},
+		{service: "iam", operation: "ListRoles", want: true},
+		{service: "s3", operation: "GetObject", want: true},
+		{service: "s3", operation: "HeadBucket", want: true},
+		{service: "sts", operation: "AssumeRole", want: true},
+		{service: "ec2", operation: "TerminateInstances", want: false},
+		{service: "s3", operation: "PutObject", want: false},
+		{service: "iam", operation: "CreateAccessKey", want: false},
+		{service: "ec2", operation: "AssumeRole", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.service+":"+tt.operation, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsAllowed(tt.service, tt.operation))
+		})
+	}
+}
+
+func TestGuard(t *testing.T) {
+	sess := Guard(session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	})))
+	sent := 0
+	sess.Handlers.Send.Clear()
+	sess.Handlers.Send.PushBack(func(r *request.Request) {
+		sent++
+		r.HTTPResponse = &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+	})
+	sess.Handlers.Unmarshal.Clear()
+	client := ec2.New(sess)
+
+	_, err := client.DescribeVpcs(&ec2.DescribeVpcsInput{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, sent)
+
+	_, err = client.DeleteVpc(&ec2.DeleteVpcInput{VpcId: aws.String("vpc-1")})
+	assert.Equal(t, 1, sent)
+	awsErr, ok := err.(awserr.Error)
+	assert.True(t, ok)
+	assert.Equal(t, ErrCodeOperationNotAllowed, awsErr.Code())
+	assert.Equal(t, "ec2:DeleteVpc is blocked, driftctl only calls read-only operations (Describe*, List*, Get*, Head*)", awsErr.Message())
+}
+
+func TestSessionPolicy(t *testing.T) {
+	var policy struct {
+		Statement []struct {
+			Effect   string
+			Action   []string
+			Resource string
+		}
+	}
+	err := json.Unmarshal([]byte(SessionPolicy("ec2", "s3")), &policy)
+	assert.Nil(t, err)
+	assert.Len(t, policy.Statement, 1)
+	assert.Equal(t, "Allow", policy.Statement[0].Effect)
+	assert.Equal(t, "*", policy.Statement[0].Resource)
+	assert.Equal(t, []string{"ec2:Describe*", "ec2:List*", "ec2:Get*", "s3:Describe*", "s3:List*", "s3:Get*"}, policy.Statement[0].Action)
+}
@@ -0,0 +1,80 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/sirupsen/logrus"
+)
+
+// RegionalSupplier records the region resources of a supplier have been found in
+type RegionalSupplier struct {
+	supplier resource.Supplier
+	region   string
+}
+
+func NewRegionalSupplier(supplier resource.Supplier, region string) *RegionalSupplier {
+	return &RegionalSupplier{supplier, region}
+}
+
+func (s RegionalSupplier) String() string {
+	return fmt.Sprintf("%s in %s", s.supplier, s.region)
+}
+
+func (s RegionalSupplier) Resources() ([]resource.Resource, error) {
+	resources, err := s.supplier.Resources()
+	if err != nil {
+		var supplierErr *resource.SupplierError
+		if errors.As(err, &supplierErr) {
+			supplierErr.Region = s.region
+		}
+		return nil, err
+	}
+	for _, res := range resources {
+		if meta := resource.GetMetadata(res); meta != nil {
+			meta.Region = s.region
+		}
+	}
+	return resources, nil
+}
+
+// resolveRegions returns regions to scan, the region of the session when none is given
+// and every region enabled for the account when AllRegions is given
+func resolveRegions(sess *session.Session, regions []string) ([]string, error) {
+	if len(regions) == 0 {
+		return []string{aws.StringValue(sess.Config.Region)}, nil
+	}
+
+	for _, region := range regions {
+		if region == AllRegions {
+			return listEnabledRegions(ec2.New(sess))
+		}
+	}
+	return regions, nil
+}
+
+func listEnabledRegions(client ec2iface.EC2API) ([]string, error) {
+	// Only regions enabled for the account are returned by default
+	output, err := client.DescribeRegions(&ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	regions := make([]string, 0, len(output.Regions))
+	for _, region := range output.Regions {
+		regions = append(regions, aws.StringValue(region.RegionName))
+	}
+	sort.Strings(regions)
+
+	logrus.WithFields(logrus.Fields{
+		"regions": regions,
+	}).Debug("Found enabled regions")
+
+	return regions, nil
+}
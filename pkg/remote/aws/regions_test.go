@@ -0,0 +1,62 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/cloudskiff/driftctl/mocks"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	testresource "github.com/cloudskiff/driftctl/test/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSupplier struct {
+	resources []resource.Resource
+	err       error
+}
+
+func (s fakeSupplier) Resources() ([]resource.Resource, error) {
+	return s.resources, s.err
+}
+
+func TestRegionalSupplier_Resources(t *testing.T) {
+	supplier := NewRegionalSupplier(fakeSupplier{
+		resources: []resource.Resource{
+			&testresource.FakeResource{Id: "foo"},
+			&testresource.FakeResource{Id: "bar"},
+		},
+	}, "eu-west-3")
+
+	got, err := supplier.Resources()
+	assert.Nil(t, err)
+	assert.Len(t, got, 2)
+	for _, res := range got {
+		assert.Equal(t, "eu-west-3", resource.GetMetadata(res).Region)
+	}
+
+	_, err = NewRegionalSupplier(fakeSupplier{err: errors.New("error")}, "eu-west-3").Resources()
+	assert.EqualError(t, err, "error")
+
+	_, err = NewRegionalSupplier(resource.NewTypedSupplier(fakeSupplier{err: errors.New("error")}, "FakeResource"), "eu-west-3").Resources()
+	var supplierErr *resource.SupplierError
+	assert.True(t, errors.As(err, &supplierErr))
+	assert.Equal(t, "eu-west-3", supplierErr.Region)
+	assert.Equal(t, []string{"FakeResource"}, supplierErr.ResourceTypes)
+}
+
+func TestListEnabledRegions(t *testing.T) {
+	client := &mocks.FakeEC2{}
+	client.On("DescribeRegions", &ec2.DescribeRegionsInput{}).Return(&ec2.DescribeRegionsOutput{
+		Regions: []*ec2.Region{
+			{RegionName: aws.String("us-east-1")},
+			{RegionName: aws.String("eu-west-3")},
+			{RegionName: aws.String("eu-west-1")},
+		},
+	}, nil)
+
+	got, err := listEnabledRegions(client)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"eu-west-1", "eu-west-3", "us-east-1"}, got)
+}
@@ -26,8 +26,8 @@ type Route53RecordSupplier struct {
 	runner       *terraform.ParallelResourceReader
 }
 
-func NewRoute53RecordSupplier(runner *parallel.ParallelRunner, client route53iface.Route53API) *Route53RecordSupplier {
-	return &Route53RecordSupplier{terraform.Provider(terraform.AWS), awsdeserializer.NewRoute53RecordDeserializer(), client, terraform.NewParallelResourceReader(runner)}
+func NewRoute53RecordSupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client route53iface.Route53API) *Route53RecordSupplier {
+	return &Route53RecordSupplier{reader, awsdeserializer.NewRoute53RecordDeserializer(), client, terraform.NewParallelResourceReader(runner)}
 }
 
 func (s Route53RecordSupplier) Resources() ([]resource.Resource, error) {
@@ -91,6 +91,10 @@ func (s Route53RecordSupplier) listRecordsForZone(zoneId string, zoneName string
 				terraform.ReadResourceArgs{
 					Ty: resourceaws.AwsRoute53RecordResourceType,
 					ID: strings.Join(vars, "_"),
+					// The provider reads the type from the ID, it is given to know default records without reading them
+					Attributes: map[string]string{
+						"type": rawType,
+					},
 				},
 			)
 			if err != nil {
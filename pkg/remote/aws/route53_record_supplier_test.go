@@ -188,7 +188,7 @@ func TestRoute53RecordSupplier_Resources(t *testing.T) {
 				}
 
 				terraform.AddProvider(terraform.AWS, provider)
-				resource.AddSupplier(NewRoute53RecordSupplier(provider.Runner(), route53.New(provider.session)))
+				resource.AddSupplier(NewRoute53RecordSupplier(provider, provider.Runner(), route53.New(provider.session)))
 			}
 
 			provider := mocks.NewMockedGoldenTFProvider(tt.dirName, terraform.Provider(terraform.AWS), shouldUpdate)
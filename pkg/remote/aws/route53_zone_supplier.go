@@ -23,8 +23,8 @@ type Route53ZoneSupplier struct {
 	runner       *terraform.ParallelResourceReader
 }
 
-func NewRoute53ZoneSupplier(runner *parallel.ParallelRunner, client route53iface.Route53API) *Route53ZoneSupplier {
-	return &Route53ZoneSupplier{terraform.Provider(terraform.AWS), awsdeserializer.NewRoute53ZoneDeserializer(), client, terraform.NewParallelResourceReader(runner)}
+func NewRoute53ZoneSupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client route53iface.Route53API) *Route53ZoneSupplier {
+	return &Route53ZoneSupplier{reader, awsdeserializer.NewRoute53ZoneDeserializer(), client, terraform.NewParallelResourceReader(runner)}
 }
 
 func listAwsRoute53Zones(client route53iface.Route53API) ([]*route53.HostedZone, error) {
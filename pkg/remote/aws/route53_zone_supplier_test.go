@@ -98,7 +98,7 @@ func TestRoute53ZoneSupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewRoute53ZoneSupplier(provider.Runner(), route53.New(provider.session)))
+			resource.AddSupplier(NewRoute53ZoneSupplier(provider, provider.Runner(), route53.New(provider.session)))
 		}
 
 		t.Run(tt.test, func(t *testing.T) {
@@ -21,9 +21,9 @@ type RouteSupplier struct {
 	routeRunner       *terraform.ParallelResourceReader
 }
 
-func NewRouteSupplier(runner *parallel.ParallelRunner, client ec2iface.EC2API) *RouteSupplier {
+func NewRouteSupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client ec2iface.EC2API) *RouteSupplier {
 	return &RouteSupplier{
-		terraform.Provider(terraform.AWS),
+		reader,
 		awsdeserializer.NewRouteDeserializer(),
 		client,
 		terraform.NewParallelResourceReader(runner.SubRunner()),
@@ -78,6 +78,13 @@ func (s RouteSupplier) readRoute(tableId string, route ec2.Route) (cty.Value, er
 	if route.DestinationIpv6CidrBlock != nil && *route.DestinationIpv6CidrBlock != "" {
 		attributes["destination_ipv6_cidr_block"] = *route.DestinationIpv6CidrBlock
 	}
+	// Middlewares tell default routes apart with these attributes, they are given for resources only listed in coverage mode
+	if route.Origin != nil {
+		attributes["origin"] = *route.Origin
+	}
+	if route.GatewayId != nil {
+		attributes["gateway_id"] = *route.GatewayId
+	}
 
 	val, err := s.reader.ReadResource(terraform.ReadResourceArgs{
 		ID:         aws.CalculateRouteID(&tableId, route.DestinationCidrBlock, route.DestinationIpv6CidrBlock),
@@ -136,7 +136,7 @@ func TestRouteSupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewRouteSupplier(provider.Runner(), ec2.New(provider.session)))
+			resource.AddSupplier(NewRouteSupplier(provider, provider.Runner(), ec2.New(provider.session)))
 		}
 
 		t.Run(c.test, func(tt *testing.T) {
@@ -140,7 +140,7 @@ func TestRouteTableAssociationSupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewRouteTableAssociationSupplier(provider.Runner(), ec2.New(provider.session)))
+			resource.AddSupplier(NewRouteTableAssociationSupplier(provider, provider.Runner(), ec2.New(provider.session)))
 		}
 
 		t.Run(c.test, func(tt *testing.T) {
@@ -87,7 +87,7 @@ func TestRouteTableSupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewRouteTableSupplier(provider.Runner(), ec2.New(provider.session)))
+			resource.AddSupplier(NewRouteTableSupplier(provider, provider.Runner(), ec2.New(provider.session)))
 		}
 
 		t.Run(c.test, func(tt *testing.T) {
@@ -64,7 +64,7 @@ func TestS3BucketAnalyticSupplier_Resources(t *testing.T) {
 
 			factory := AwsClientFactory{config: provider.session}
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewS3BucketAnalyticSupplier(provider.Runner().SubRunner(), factory))
+			resource.AddSupplier(NewS3BucketAnalyticSupplier(provider, provider.Runner().SubRunner(), factory))
 		}
 
 		t.Run(tt.test, func(t *testing.T) {
@@ -65,7 +65,7 @@ func TestS3BucketInventorySupplier_Resources(t *testing.T) {
 			factory := AwsClientFactory{config: provider.session}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewS3BucketInventorySupplier(provider.Runner().SubRunner(), factory))
+			resource.AddSupplier(NewS3BucketInventorySupplier(provider, provider.Runner().SubRunner(), factory))
 		}
 
 		t.Run(tt.test, func(t *testing.T) {
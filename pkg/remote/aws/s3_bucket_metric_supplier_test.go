@@ -65,7 +65,7 @@ func TestS3BucketMetricSupplier_Resources(t *testing.T) {
 			factory := AwsClientFactory{config: provider.session}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewS3BucketMetricSupplier(provider.Runner().SubRunner(), factory))
+			resource.AddSupplier(NewS3BucketMetricSupplier(provider, provider.Runner().SubRunner(), factory))
 		}
 
 		t.Run(tt.test, func(t *testing.T) {
@@ -61,7 +61,7 @@ func TestS3BucketNotificationSupplier_Resources(t *testing.T) {
 			factory := AwsClientFactory{config: provider.session}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewS3BucketNotificationSupplier(provider.Runner().SubRunner(), factory))
+			resource.AddSupplier(NewS3BucketNotificationSupplier(provider, provider.Runner().SubRunner(), factory))
 		}
 
 		t.Run(tt.test, func(t *testing.T) {
@@ -61,7 +61,7 @@ func TestS3BucketPolicySupplier_Resources(t *testing.T) {
 			factory := AwsClientFactory{config: provider.session}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewS3BucketPolicySupplier(provider.Runner().SubRunner(), factory))
+			resource.AddSupplier(NewS3BucketPolicySupplier(provider, provider.Runner().SubRunner(), factory))
 		}
 
 		t.Run(tt.test, func(t *testing.T) {
@@ -0,0 +1,129 @@
+package aws
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+)
+
+// sharedListing runs a listing once, every caller gets the pages it returned.
+// It is concurrency safe, callers coming while the listing runs wait for it.
+type sharedListing struct {
+	once  sync.Once
+	pages []interface{}
+	err   error
+}
+
+func (l *sharedListing) get(list func(page func(interface{})) error) ([]interface{}, error) {
+	l.once.Do(func() {
+		l.err = list(func(page interface{}) {
+			l.pages = append(l.pages, page)
+		})
+	})
+	return l.pages, l.err
+}
+
+// sharedEC2 shares listings of a region between the suppliers of a scan,
+// e.g. security groups are listed once for security group and security group rule suppliers.
+// Only listings without filters are shared, other calls go to the wrapped client.
+type sharedEC2 struct {
+	ec2iface.EC2API
+	addresses      sharedListing
+	securityGroups sharedListing
+	routeTables    sharedListing
+}
+
+func newSharedEC2(client ec2iface.EC2API) *sharedEC2 {
+	return &sharedEC2{EC2API: client}
+}
+
+func (c *sharedEC2) DescribeAddresses(input *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+	if input.AllocationIds != nil || input.Filters != nil || input.PublicIps != nil {
+		return c.EC2API.DescribeAddresses(input)
+	}
+	pages, err := c.addresses.get(func(page func(interface{})) error {
+		output, err := c.EC2API.DescribeAddresses(input)
+		page(output)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pages[0].(*ec2.DescribeAddressesOutput), nil
+}
+
+func (c *sharedEC2) DescribeSecurityGroupsPages(input *ec2.DescribeSecurityGroupsInput, fn func(*ec2.DescribeSecurityGroupsOutput, bool) bool) error {
+	if input.Filters != nil || input.GroupIds != nil || input.GroupNames != nil || input.MaxResults != nil || input.NextToken != nil {
+		return c.EC2API.DescribeSecurityGroupsPages(input, fn)
+	}
+	pages, err := c.securityGroups.get(func(page func(interface{})) error {
+		return c.EC2API.DescribeSecurityGroupsPages(input, func(output *ec2.DescribeSecurityGroupsOutput, lastPage bool) bool {
+			page(output)
+			return true
+		})
+	})
+	if err != nil {
+		return err
+	}
+	for i, page := range pages {
+		if !fn(page.(*ec2.DescribeSecurityGroupsOutput), i == len(pages)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func (c *sharedEC2) DescribeRouteTablesPages(input *ec2.DescribeRouteTablesInput, fn func(*ec2.DescribeRouteTablesOutput, bool) bool) error {
+	if input.Filters != nil || input.RouteTableIds != nil || input.MaxResults != nil || input.NextToken != nil {
+		return c.EC2API.DescribeRouteTablesPages(input, fn)
+	}
+	pages, err := c.routeTables.get(func(page func(interface{})) error {
+		return c.EC2API.DescribeRouteTablesPages(input, func(output *ec2.DescribeRouteTablesOutput, lastPage bool) bool {
+			page(output)
+			return true
+		})
+	})
+	if err != nil {
+		return err
+	}
+	for i, page := range pages {
+		if !fn(page.(*ec2.DescribeRouteTablesOutput), i == len(pages)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+// sharedRoute53 shares the listing of hosted zones between zone and record suppliers of a scan
+type sharedRoute53 struct {
+	route53iface.Route53API
+	hostedZones sharedListing
+}
+
+func newSharedRoute53(client route53iface.Route53API) *sharedRoute53 {
+	return &sharedRoute53{Route53API: client}
+}
+
+func (c *sharedRoute53) ListHostedZonesPages(input *route53.ListHostedZonesInput, fn func(*route53.ListHostedZonesOutput, bool) bool) error {
+	if *input != (route53.ListHostedZonesInput{}) {
+		return c.Route53API.ListHostedZonesPages(input, fn)
+	}
+	pages, err := c.hostedZones.get(func(page func(interface{})) error {
+		return c.Route53API.ListHostedZonesPages(input, func(output *route53.ListHostedZonesOutput, lastPage bool) bool {
+			page(output)
+			return true
+		})
+	})
+	if err != nil {
+		return err
+	}
+	for i, page := range pages {
+		if !fn(page.(*route53.ListHostedZonesOutput), i == len(pages)-1) {
+			break
+		}
+	}
+	return nil
+}
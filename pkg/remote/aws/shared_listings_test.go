@@ -0,0 +1,135 @@
+package aws
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/cloudskiff/driftctl/mocks"
+	mocks2 "github.com/cloudskiff/driftctl/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSharedEC2_DescribeSecurityGroupsPages(t *testing.T) {
+	client := mocks.FakeEC2{}
+	client.On("DescribeSecurityGroupsPages",
+		&ec2.DescribeSecurityGroupsInput{},
+		mock.MatchedBy(func(callback func(res *ec2.DescribeSecurityGroupsOutput, lastPage bool) bool) bool {
+			callback(&ec2.DescribeSecurityGroupsOutput{
+				SecurityGroups: []*ec2.SecurityGroup{{GroupId: aws.String("sg-1")}},
+			}, false)
+			callback(&ec2.DescribeSecurityGroupsOutput{
+				SecurityGroups: []*ec2.SecurityGroup{{GroupId: aws.String("sg-2")}},
+			}, true)
+			return true
+		})).Return(nil).Once()
+
+	shared := newSharedEC2(&client)
+
+	// Suppliers of security groups and rules list concurrently
+	wg := sync.WaitGroup{}
+	results := make([][]string, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := shared.DescribeSecurityGroupsPages(&ec2.DescribeSecurityGroupsInput{}, func(res *ec2.DescribeSecurityGroupsOutput, lastPage bool) bool {
+				for _, group := range res.SecurityGroups {
+					results[i] = append(results[i], *group.GroupId)
+				}
+				return !lastPage
+			})
+			assert.Nil(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		assert.Equal(t, []string{"sg-1", "sg-2"}, result)
+	}
+	client.AssertExpectations(t)
+}
+
+func TestSharedEC2_DescribeRouteTablesPages_Error(t *testing.T) {
+	client := mocks.FakeEC2{}
+	client.On("DescribeRouteTablesPages", &ec2.DescribeRouteTablesInput{}, mock.Anything).Return(errors.New("access denied")).Once()
+
+	shared := newSharedEC2(&client)
+
+	for i := 0; i < 3; i++ {
+		err := shared.DescribeRouteTablesPages(&ec2.DescribeRouteTablesInput{}, func(res *ec2.DescribeRouteTablesOutput, lastPage bool) bool {
+			return true
+		})
+		assert.EqualError(t, err, "access denied")
+	}
+	client.AssertExpectations(t)
+}
+
+func TestSharedEC2_DescribeAddresses_Filtered(t *testing.T) {
+	filtered := &ec2.DescribeAddressesInput{PublicIps: []*string{aws.String("1.2.3.4")}}
+	client := mocks.FakeEC2{}
+	client.On("DescribeAddresses", &ec2.DescribeAddressesInput{}).Return(&ec2.DescribeAddressesOutput{
+		Addresses: []*ec2.Address{{AllocationId: aws.String("eipalloc-1")}},
+	}, nil).Once()
+	client.On("DescribeAddresses", filtered).Return(&ec2.DescribeAddressesOutput{}, nil).Twice()
+
+	shared := newSharedEC2(&client)
+
+	for i := 0; i < 2; i++ {
+		output, err := shared.DescribeAddresses(&ec2.DescribeAddressesInput{})
+		assert.Nil(t, err)
+		assert.Len(t, output.Addresses, 1)
+
+		// Filtered listings are not shared
+		output, err = shared.DescribeAddresses(filtered)
+		assert.Nil(t, err)
+		assert.Len(t, output.Addresses, 0)
+	}
+	client.AssertExpectations(t)
+}
+
+type countingRoute53Client struct {
+	*mocks2.MockAWSRoute53Client
+	calls int
+}
+
+func (c *countingRoute53Client) ListHostedZonesPages(input *route53.ListHostedZonesInput, cb func(*route53.ListHostedZonesOutput, bool) bool) error {
+	c.calls++
+	return c.MockAWSRoute53Client.ListHostedZonesPages(input, cb)
+}
+
+func TestSharedRoute53_ListHostedZonesPages(t *testing.T) {
+	client := &countingRoute53Client{
+		MockAWSRoute53Client: mocks2.NewMockAWSRoute53ZoneClient(mocks2.ListHostedZonesPagesOutput{
+			{
+				LastPage: false,
+				Response: &route53.ListHostedZonesOutput{HostedZones: []*route53.HostedZone{{Id: aws.String("Z01")}}},
+			},
+			{
+				LastPage: true,
+				Response: &route53.ListHostedZonesOutput{HostedZones: []*route53.HostedZone{{Id: aws.String("Z02")}}},
+			},
+		}),
+	}
+
+	shared := newSharedRoute53(client)
+
+	zones, err := listAwsRoute53Zones(shared)
+	assert.Nil(t, err)
+	assert.Len(t, zones, 2)
+
+	// A caller stopping early only gets the first page
+	pages := 0
+	err = shared.ListHostedZonesPages(&route53.ListHostedZonesInput{}, func(res *route53.ListHostedZonesOutput, lastPage bool) bool {
+		pages++
+		return false
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, pages)
+
+	assert.Equal(t, 1, client.calls)
+}
@@ -2,21 +2,22 @@ package aws
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
-	"time"
 
 	"github.com/cloudskiff/driftctl/pkg/parallel"
+	"github.com/cloudskiff/driftctl/pkg/remote/aws/readonly"
+	resourceaws "github.com/cloudskiff/driftctl/pkg/resource/aws"
 	"github.com/sirupsen/logrus"
 
 	tf "github.com/cloudskiff/driftctl/pkg/terraform"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/eapache/go-resiliency/retrier"
-	"github.com/hashicorp/terraform/plugin"
 	"github.com/hashicorp/terraform/plugin/discovery"
 	"github.com/hashicorp/terraform/providers"
 	"github.com/hashicorp/terraform/terraform"
@@ -29,20 +30,20 @@ type awsConfig struct {
 	AccessKey     string
 	SecretKey     string
 	CredsFilename string
-	Profile       string
+	Profile       string `cty:"profile"`
 	Token         string
 	Region        string `cty:"region"`
-	MaxRetries    int
+	MaxRetries    int    `cty:"max_retries"`
 
 	AssumeRoleARN         string
 	AssumeRoleExternalID  string
 	AssumeRoleSessionName string
 	AssumeRolePolicy      string
 
-	AllowedAccountIds   []string
-	ForbiddenAccountIds []string
+	AllowedAccountIds   []string `cty:"allowed_account_ids"`
+	ForbiddenAccountIds []string `cty:"forbidden_account_ids"`
 
-	Endpoints        map[string]string
+	Endpoints        map[string]string // Custom endpoints by service (e.g. ec2, iam, s3, sts)
 	IgnoreTagsConfig map[string]string
 	Insecure         bool
 
@@ -51,33 +52,52 @@ type awsConfig struct {
 	SkipRegionValidation    bool
 	SkipRequestingAccountId bool
 	SkipMetadataApiCheck    bool
-	S3ForcePathStyle        bool
+	S3ForcePathStyle        bool `cty:"s3_force_path_style"`
 }
 
 type TerraformProvider struct {
 	lock             sync.Mutex
 	providerSupplier *tf.ProviderInstaller
 	session          *session.Session
-	grpcProviders    map[string]*plugin.GRPCProvider
+	grpcProviders    map[string]providers.Interface
+	configureErrors  map[string]error // Regions whose provider failed to configure are not configured again
 	schemas          map[string]providers.Schema
 	defaultRegion    string
 	runner           *parallel.ParallelRunner
+	config           awsConfig
+	readCache        tf.ReadCacheOptions
+	account          string
+	listOnly         bool
 }
 
 func NewTerraFormProvider() (*TerraformProvider, error) {
-	provider, err := tf.NewProviderInstaller()
+	return newTerraFormProvider(awsConfig{}, tf.ProviderInstallerOptions{}, parallel.DefaultMaxRun)
+}
+
+// newTerraFormProvider creates a provider authenticated with the given config,
+// the region is set for each region the provider is configured for.
+// At most parallelism resources are read at once, fewer when reads are throttled.
+func newTerraFormProvider(config awsConfig, installerOptions tf.ProviderInstallerOptions, parallelism int) (*TerraformProvider, error) {
+	provider, err := tf.NewProviderInstaller(installerOptions)
 	if err != nil {
 		return nil, err
 	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = 10
+	}
+	if parallelism <= 0 {
+		parallelism = parallel.DefaultMaxRun
+	}
 	p := TerraformProvider{
 		providerSupplier: provider,
-		runner:           parallel.NewParallelRunner(context.TODO(), 10),
-		grpcProviders:    make(map[string]*plugin.GRPCProvider),
+		runner:           parallel.NewParallelRunner(context.TODO(), int64(parallelism)),
+		grpcProviders:    make(map[string]providers.Interface),
+		config:           config,
 	}
 	p.initSession()
 	p.defaultRegion = *p.session.Config.Region
 	stopCh := make(chan bool)
-	c := make(chan os.Signal)
+	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		select {
@@ -96,7 +116,6 @@ func NewTerraFormProvider() (*TerraformProvider, error) {
 	if err != nil {
 		return nil, err
 	}
-	fmt.Printf("Scanning AWS on region: %s\n", p.defaultRegion)
 	return &p, nil
 }
 
@@ -104,16 +123,45 @@ func (p *TerraformProvider) Schema() map[string]providers.Schema {
 	return p.schemas
 }
 
+// Version returns the version of the terraform provider
+func (p *TerraformProvider) Version() string {
+	return p.providerSupplier.AwsVersion()
+}
+
 func (p *TerraformProvider) Runner() *parallel.ParallelRunner {
 	return p.runner
 }
 
+// initSession creates the session used to list resources, it is guarded to only call read-only operations
 func (p *TerraformProvider) initSession() {
-	p.session = session.Must(session.NewSessionWithOptions(session.Options{
+	p.session = readonly.Guard(session.Must(session.NewSessionWithOptions(session.Options{
 		SharedConfigState: session.SharedConfigEnable,
-	}))
+		Profile:           p.config.Profile,
+		Config: aws.Config{
+			MaxRetries:       aws.Int(p.config.MaxRetries),
+			EndpointResolver: endpointResolver(p.config.Endpoints),
+			S3ForcePathStyle: aws.Bool(p.config.S3ForcePathStyle),
+		},
+	})))
+	if p.config.AssumeRoleARN != "" {
+		p.session = p.session.Copy(&aws.Config{
+			Credentials: stscreds.NewCredentials(p.session, p.config.AssumeRoleARN, func(provider *stscreds.AssumeRoleProvider) {
+				if p.config.AssumeRoleExternalID != "" {
+					provider.ExternalID = aws.String(p.config.AssumeRoleExternalID)
+				}
+				if p.config.AssumeRoleSessionName != "" {
+					provider.RoleSessionName = p.config.AssumeRoleSessionName
+				}
+				if p.config.AssumeRolePolicy != "" {
+					provider.Policy = aws.String(p.config.AssumeRolePolicy)
+				}
+			}),
+		})
+	}
 }
 
+// configure starts and configures the provider of a region, it must be called with the lock held.
+// The provider is only stored once configured, a provider that failed to configure is never used to read resources
 func (p *TerraformProvider) configure(region string) error {
 
 	providerPath, err := p.providerSupplier.GetAws()
@@ -123,48 +171,198 @@ func (p *TerraformProvider) configure(region string) error {
 
 	logrus.WithFields(logrus.Fields{
 		"region": region,
-	}).Debug("Starting new provider")
-	if p.grpcProviders[region] == nil {
-		logrus.WithFields(logrus.Fields{
-			"region": region,
-		}).Debug("Starting aws provider GRPC client")
-		GRPCProvider, err := tf.NewTerraformProvider(discovery.PluginMeta{
-			Path: providerPath,
-		})
-
-		if err != nil {
-			return err
-		}
-		p.grpcProviders[region] = GRPCProvider
+	}).Debug("Starting aws provider GRPC client")
+	GRPCProvider, err := tf.NewTerraformProvider(discovery.PluginMeta{
+		Path: providerPath,
+	})
+	if err != nil {
+		return err
 	}
+	provider := tf.NewReadOnlyProvider(GRPCProvider)
 
-	schema := p.grpcProviders[region].GetSchema()
+	schema := provider.GetSchema()
 	if p.schemas == nil {
 		p.schemas = schema.ResourceTypes
 	}
-	configType := schema.Provider.Block.ImpliedType()
-	val, err := gocty.ToCtyValue(getConfig(region), configType)
+	config := p.config
+	config.Region = region
+	val, err := config.ctyValue(schema.Provider.Block.ImpliedType())
 	if err != nil {
+		closeProvider(provider, region)
 		return err
 	}
-	resp := p.grpcProviders[region].Configure(providers.ConfigureRequest{
+	resp := provider.Configure(providers.ConfigureRequest{
 		Config: val,
 	})
 
 	if resp.Diagnostics.HasErrors() {
+		closeProvider(provider, region)
 		return resp.Diagnostics.Err()
 	}
 
+	p.grpcProviders[region] = provider
 	return nil
 }
 
-func getConfig(region string) awsConfig {
-	return awsConfig{
-		Region:     region,
-		MaxRetries: 10, // TODO make this configurable
+// closeProvider stops the plugin process of a provider that will never be used
+func closeProvider(provider providers.Interface, region string) {
+	if err := provider.Close(); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"region": region,
+		}).Debugf("Unable to stop aws provider: %s", err)
 	}
 }
 
+type assumeRoleConfig struct {
+	RoleARN     string  `cty:"role_arn"`
+	ExternalID  *string `cty:"external_id"`
+	SessionName *string `cty:"session_name"`
+}
+
+// ctyValue converts the config to the configuration of the terraform provider
+func (c awsConfig) ctyValue(configType cty.Type) (cty.Value, error) {
+	val, err := gocty.ToCtyValue(c, configType)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	if len(c.Endpoints) > 0 {
+		val, err = c.withEndpoints(val, configType)
+		if err != nil {
+			return cty.NilVal, err
+		}
+	}
+	if c.AssumeRoleARN == "" {
+		return val, nil
+	}
+
+	// assume_role is a nested block of the provider configuration
+	assumeRoleType := configType.AttributeType("assume_role")
+	assumeRole, err := gocty.ToCtyValue(assumeRoleConfig{
+		RoleARN:     c.AssumeRoleARN,
+		ExternalID:  optionalString(c.AssumeRoleExternalID),
+		SessionName: optionalString(c.AssumeRoleSessionName),
+	}, assumeRoleType.ElementType())
+	if err != nil {
+		return cty.NilVal, err
+	}
+	if c.AssumeRolePolicy != "" {
+		if !assumeRoleType.ElementType().HasAttribute("policy") {
+			return cty.NilVal, errors.New("Session policies of assumed roles are not supported by this version of the terraform AWS provider")
+		}
+		roleAttributes := assumeRole.AsValueMap()
+		roleAttributes["policy"] = cty.StringVal(c.AssumeRolePolicy)
+		assumeRole = cty.ObjectVal(roleAttributes)
+	}
+
+	attributes := val.AsValueMap()
+	if assumeRoleType.IsSetType() {
+		attributes["assume_role"] = cty.SetVal([]cty.Value{assumeRole})
+	} else {
+		attributes["assume_role"] = cty.ListVal([]cty.Value{assumeRole})
+	}
+	return cty.ObjectVal(attributes), nil
+}
+
+// withEndpoints adds the endpoints nested block, with one attribute per service, to the provider configuration
+func (c awsConfig) withEndpoints(val cty.Value, configType cty.Type) (cty.Value, error) {
+	endpointsType := configType.AttributeType("endpoints")
+	services := endpointsType.ElementType().AttributeTypes()
+	endpoints := make(map[string]cty.Value, len(services))
+	for service := range services {
+		endpoints[service] = cty.NullVal(cty.String)
+	}
+	for service, url := range c.Endpoints {
+		if _, exists := services[service]; !exists {
+			return cty.NilVal, errors.Errorf("Unsupported AWS endpoint service: %s", service)
+		}
+		endpoints[service] = cty.StringVal(url)
+	}
+
+	attributes := val.AsValueMap()
+	if endpointsType.IsSetType() {
+		attributes["endpoints"] = cty.SetVal([]cty.Value{cty.ObjectVal(endpoints)})
+	} else {
+		attributes["endpoints"] = cty.ListVal([]cty.Value{cty.ObjectVal(endpoints)})
+	}
+	return cty.ObjectVal(attributes), nil
+}
+
+// endpointResolver resolves services to their custom endpoint if any, to the AWS endpoint otherwise
+func endpointResolver(custom map[string]string) endpoints.Resolver {
+	return endpoints.ResolverFunc(func(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+		if url, exists := custom[service]; exists {
+			return endpoints.ResolvedEndpoint{
+				URL:           url,
+				SigningRegion: region,
+			}, nil
+		}
+		return endpoints.DefaultResolver().EndpointFor(service, region, opts...)
+	})
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// SetReadCache caches reads of the provider on disk, reads are cached for the given account only
+func (p *TerraformProvider) SetReadCache(options tf.ReadCacheOptions, account string) {
+	p.readCache = options
+	p.account = account
+}
+
+// SetListOnly makes resources not being read anymore, the provider only returns what is known from listing them
+// (e.g. their ID), which is enough to compute the coverage of IaC
+func (p *TerraformProvider) SetListOnly(listOnly bool) {
+	p.listOnly = listOnly
+}
+
+// DefaultRegion returns the region of the AWS configuration, the provider is configured for it without alias
+func (p *TerraformProvider) DefaultRegion() string {
+	return p.defaultRegion
+}
+
+// Reader returns the reader of resources, they are read in the default region unless given an aws_region attribute
+func (p *TerraformProvider) Reader() tf.ResourceReader {
+	return p.cached(p, p.defaultRegion)
+}
+
+// RegionalReader returns a reader of resources located in the given region
+func (p *TerraformProvider) RegionalReader(region string) tf.ResourceReader {
+	return p.cached(regionalReader{p, region}, region)
+}
+
+func (p *TerraformProvider) cached(reader tf.ResourceReader, region string) tf.ResourceReader {
+	// Resources known from listing only must not be taken for resources read
+	if !p.readCache.Enabled() || p.listOnly {
+		return reader
+	}
+	return tf.NewCachedResourceReader(reader, p.readCache, tf.ReadCacheScope{
+		ProviderVersion: p.Version(),
+		Account:         p.account,
+		Region:          region,
+	})
+}
+
+type regionalReader struct {
+	provider *TerraformProvider
+	region   string
+}
+
+func (r regionalReader) ReadResource(args tf.ReadResourceArgs) (*cty.Value, error) {
+	attributes := make(map[string]string, len(args.Attributes)+1)
+	for k, v := range args.Attributes {
+		attributes[k] = v
+	}
+	if attributes["aws_region"] == "" {
+		attributes["aws_region"] = r.region
+	}
+	args.Attributes = attributes
+	return r.provider.ReadResource(args)
+}
+
 func (p *TerraformProvider) ReadResource(args tf.ReadResourceArgs) (*cty.Value, error) {
 
 	logrus.WithFields(logrus.Fields{
@@ -185,15 +383,6 @@ func (p *TerraformProvider) ReadResource(args tf.ReadResourceArgs) (*cty.Value,
 		delete(args.Attributes, "aws_region")
 	}
 
-	p.lock.Lock()
-	if p.grpcProviders[region] == nil {
-		err := p.configure(region)
-		if err != nil {
-			return nil, err
-		}
-	}
-	p.lock.Unlock()
-
 	if args.Attributes != nil && len(args.Attributes) > 0 {
 		// call to the provider sometimes add and delete field to their attribute this may broke caller so we deep copy attributes
 		state.Attributes = make(map[string]string, len(args.Attributes))
@@ -209,18 +398,49 @@ func (p *TerraformProvider) ReadResource(args tf.ReadResourceArgs) (*cty.Value,
 		return nil, err
 	}
 
-	var newState cty.Value
-	r := retrier.New(retrier.ConstantBackoff(3, 100*time.Millisecond), nil)
+	if p.listOnly {
+		priorState = resourceaws.ConformValue(typ, priorState)
+		return &priorState, nil
+	}
+
+	// Providers of other regions may be configured concurrently, the map is only accessed with the lock held
+	p.lock.Lock()
+	if err, failed := p.configureErrors[region]; failed {
+		p.lock.Unlock()
+		return nil, err
+	}
+	if p.grpcProviders[region] == nil {
+		err := p.configure(region)
+		if err != nil {
+			if p.configureErrors == nil {
+				p.configureErrors = make(map[string]error)
+			}
+			p.configureErrors[region] = err
+			p.lock.Unlock()
+			return nil, err
+		}
+	}
+	provider := p.grpcProviders[region]
+	p.lock.Unlock()
 
-	err = r.Run(func() error {
-		resp := p.grpcProviders[region].ReadResource(providers.ReadResourceRequest{
+	var newState cty.Value
+	err = newReadRetrier().Run(func() error {
+		resp := provider.ReadResource(providers.ReadResourceRequest{
 			TypeName:     typ,
 			PriorState:   priorState,
 			Private:      []byte{},
 			ProviderMeta: cty.NullVal(cty.DynamicPseudoType),
 		})
 		if resp.Diagnostics.HasErrors() {
-			return resp.Diagnostics.Err()
+			err := resp.Diagnostics.Err()
+			if isThrottlingError(err) {
+				logrus.WithFields(logrus.Fields{
+					"type": typ,
+					"id":   args.ID,
+				}).Debugf("Read has been throttled: %s", err)
+				p.runner.Throttled()
+			}
+			return err
 		}
 		nonFatalErr := resp.Diagnostics.NonFatalErr()
 		if resp.NewState.IsNull() && nonFatalErr != nil {
@@ -233,5 +453,6 @@ func (p *TerraformProvider) ReadResource(args tf.ReadResourceArgs) (*cty.Value,
 	if err != nil {
 		return nil, err
 	}
+	newState = resourceaws.ConformValue(typ, newState)
 	return &newState, nil
 }
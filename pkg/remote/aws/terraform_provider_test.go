@@ -0,0 +1,219 @@
+package aws
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/remote/aws/readonly"
+	tf "github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestAwsConfig_CtyValue(t *testing.T) {
+	configType := cty.Object(map[string]cty.Type{
+		"region": cty.String,
+		"assume_role": cty.List(cty.Object(map[string]cty.Type{
+			"role_arn":     cty.String,
+			"external_id":  cty.String,
+			"session_name": cty.String,
+			"policy":       cty.String,
+		})),
+	})
+
+	got, err := awsConfig{Region: "eu-west-3"}.ctyValue(configType)
+	assert.Nil(t, err)
+	assert.Equal(t, "eu-west-3", got.GetAttr("region").AsString())
+	assert.True(t, got.GetAttr("assume_role").IsNull())
+
+	got, err = awsConfig{
+		Region:                "eu-west-3",
+		AssumeRoleARN:         "arn:aws:iam::123456789012:role/audit",
+		AssumeRoleSessionName: "driftctl",
+	}.ctyValue(configType)
+	assert.Nil(t, err)
+	assumeRoles := got.GetAttr("assume_role").AsValueSlice()
+	assert.Len(t, assumeRoles, 1)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/audit", assumeRoles[0].GetAttr("role_arn").AsString())
+	assert.True(t, assumeRoles[0].GetAttr("external_id").IsNull())
+	assert.Equal(t, "driftctl", assumeRoles[0].GetAttr("session_name").AsString())
+	assert.True(t, assumeRoles[0].GetAttr("policy").IsNull())
+
+	config := Options{AssumeRoleReadOnly: true}.config()
+	config.AssumeRoleARN = "arn:aws:iam::123456789012:role/audit"
+	got, err = config.ctyValue(configType)
+	assert.Nil(t, err)
+	assumeRoles = got.GetAttr("assume_role").AsValueSlice()
+	assert.Equal(t, readonly.SessionPolicy(scannedServices...), assumeRoles[0].GetAttr("policy").AsString())
+
+	_, err = config.ctyValue(cty.Object(map[string]cty.Type{
+		"region": cty.String,
+		"assume_role": cty.List(cty.Object(map[string]cty.Type{
+			"role_arn": cty.String,
+		})),
+	}))
+	assert.EqualError(t, err, "Session policies of assumed roles are not supported by this version of the terraform AWS provider")
+}
+
+func TestAwsConfig_CtyValueEndpoints(t *testing.T) {
+	configType := cty.Object(map[string]cty.Type{
+		"region":              cty.String,
+		"s3_force_path_style": cty.Bool,
+		"endpoints": cty.Set(cty.Object(map[string]cty.Type{
+			"ec2": cty.String,
+			"s3":  cty.String,
+			"sts": cty.String,
+		})),
+	})
+
+	got, err := awsConfig{Region: "us-east-1"}.ctyValue(configType)
+	assert.Nil(t, err)
+	assert.True(t, got.GetAttr("endpoints").IsNull())
+	assert.False(t, got.GetAttr("s3_force_path_style").True())
+
+	got, err = awsConfig{
+		Region:           "us-east-1",
+		Endpoints:        map[string]string{"ec2": "http://localhost:4566", "s3": "http://localhost:4566"},
+		S3ForcePathStyle: true,
+	}.ctyValue(configType)
+	assert.Nil(t, err)
+	assert.True(t, got.GetAttr("s3_force_path_style").True())
+	endpoints := got.GetAttr("endpoints").AsValueSlice()
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, "http://localhost:4566", endpoints[0].GetAttr("ec2").AsString())
+	assert.Equal(t, "http://localhost:4566", endpoints[0].GetAttr("s3").AsString())
+	assert.True(t, endpoints[0].GetAttr("sts").IsNull())
+
+	_, err = awsConfig{Endpoints: map[string]string{"foobar": "http://localhost:4566"}}.ctyValue(configType)
+	assert.EqualError(t, err, "Unsupported AWS endpoint service: foobar")
+}
+
+func TestEndpointResolver(t *testing.T) {
+	resolver := endpointResolver(map[string]string{"ec2": "http://localhost:4566"})
+
+	got, err := resolver.EndpointFor("ec2", "eu-west-3")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://localhost:4566", got.URL)
+	assert.Equal(t, "eu-west-3", got.SigningRegion)
+
+	got, err = resolver.EndpointFor("iam", "eu-west-3")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://iam.amazonaws.com", got.URL)
+
+	want, _ := endpoints.DefaultResolver().EndpointFor("s3", "eu-west-3")
+	got, err = resolver.EndpointFor("s3", "eu-west-3")
+	assert.Nil(t, err)
+	assert.Equal(t, want.URL, got.URL)
+}
+
+func TestUnsupportedAttributesAlerts(t *testing.T) {
+	provider := &TerraformProvider{
+		providerSupplier: &tf.ProviderInstaller{},
+		schemas: map[string]providers.Schema{
+			"aws_s3_bucket_policy": {
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"bucket":                {Type: cty.String, Required: true},
+						"id":                    {Type: cty.String, Computed: true},
+						"policy":                {Type: cty.String, Required: true},
+						"expected_bucket_owner": {Type: cty.String, Optional: true},
+					},
+				},
+			},
+			"aws_iam_user_policy": {
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"id":          {Type: cty.String, Computed: true},
+						"name":        {Type: cty.String, Optional: true},
+						"name_prefix": {Type: cty.String, Optional: true},
+						"policy":      {Type: cty.String, Required: true},
+						"user":        {Type: cty.String, Required: true},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, alerter.Alerts{
+		"aws_s3_bucket_policy": []alerter.Alert{
+			{Message: "aws_s3_bucket_policy attributes of AWS provider 3.19.0 are not compared: expected_bucket_owner"},
+		},
+	}, unsupportedAttributesAlerts(provider, nil))
+}
+
+func TestReadResource_ListOnly(t *testing.T) {
+	provider := &TerraformProvider{
+		providerSupplier: &tf.ProviderInstaller{},
+		defaultRegion:    "us-east-1",
+		listOnly:         true,
+		schemas: map[string]providers.Schema{
+			"aws_route53_record": {
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"id":      {Type: cty.String, Computed: true},
+						"type":    {Type: cty.String, Required: true},
+						"zone_id": {Type: cty.String, Required: true},
+					},
+				},
+			},
+		},
+	}
+
+	// No grpc provider is configured, the listing attributes are returned as is
+	got, err := provider.ReadResource(tf.ReadResourceArgs{
+		Ty:         "aws_route53_record",
+		ID:         "Z1035360GLIB82T1EH2G_test.example.com_A",
+		Attributes: map[string]string{"type": "A", "aws_region": "eu-west-3"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Z1035360GLIB82T1EH2G_test.example.com_A", got.GetAttr("id").AsString())
+	assert.Equal(t, "A", got.GetAttr("type").AsString())
+	assert.True(t, got.GetAttr("zone_id").IsNull())
+	assert.Empty(t, provider.grpcProviders)
+}
+
+func TestReadResource_ConfigureErrorCached(t *testing.T) {
+	providerPath := path.Join(t.TempDir(), "terraform-provider-aws")
+	installer, err := tf.NewProviderInstaller(tf.ProviderInstallerOptions{ProviderPath: providerPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := &TerraformProvider{
+		providerSupplier: installer,
+		defaultRegion:    "us-east-1",
+		grpcProviders:    make(map[string]providers.Interface),
+		schemas: map[string]providers.Schema{
+			"aws_instance": {
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"id": {Type: cty.String, Computed: true},
+					},
+				},
+			},
+		},
+	}
+	args := func() tf.ReadResourceArgs {
+		return tf.ReadResourceArgs{
+			Ty:         "aws_instance",
+			ID:         "i-0123456789",
+			Attributes: map[string]string{"aws_region": "ap-east-1"},
+		}
+	}
+
+	_, err = provider.ReadResource(args())
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to find provider binary")
+
+	// The region is not configured again, even once the provider could be started
+	if _, err := os.Create(providerPath); err != nil {
+		t.Fatal(err)
+	}
+	_, secondErr := provider.ReadResource(args())
+	assert.Equal(t, err, secondErr)
+	assert.Empty(t, provider.grpcProviders)
+}
@@ -0,0 +1,64 @@
+package aws
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/eapache/go-resiliency/retrier"
+)
+
+// Error codes of AWS APIs rate limiting requests, the terraform provider only gives them in error messages
+var throttlingErrorCodes = []string{
+	"Throttling",
+	"ThrottledException",
+	"RequestLimitExceeded",
+	"RequestThrottled",
+	"TooManyRequestsException",
+	"PriorRequestNotComplete",
+	"SlowDown",
+	"Rate exceeded",
+}
+
+// isThrottlingError returns true for errors of requests rate limited by AWS, they succeed once retried later
+func isThrottlingError(err error) bool {
+	if request.IsErrorThrottle(err) {
+		return true
+	}
+	message := err.Error()
+	for _, code := range throttlingErrorCodes {
+		if strings.Contains(message, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// readClassifier retries throttled reads until the backoff is exhausted,
+// other errors are retried maxErrors times only
+type readClassifier struct {
+	maxErrors int
+	errors    int
+}
+
+func (c *readClassifier) Classify(err error) retrier.Action {
+	if err == nil {
+		return retrier.Succeed
+	}
+	if isThrottlingError(err) {
+		return retrier.Retry
+	}
+	c.errors++
+	if c.errors > c.maxErrors {
+		return retrier.Fail
+	}
+	return retrier.Retry
+}
+
+// newReadRetrier returns a retrier with an exponential backoff, randomized so that
+// routines throttled at the same time are not retried at the same time
+func newReadRetrier() *retrier.Retrier {
+	r := retrier.New(retrier.ExponentialBackoff(8, 100*time.Millisecond), &readClassifier{maxErrors: 3})
+	r.SetJitter(0.5)
+	return r
+}
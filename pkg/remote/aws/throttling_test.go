@@ -0,0 +1,59 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/eapache/go-resiliency/retrier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsThrottlingError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "SDK throttling error",
+			err:  awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil),
+			want: true,
+		},
+		{
+			name: "provider throttling error",
+			err:  errors.New("error reading IAM policy: Throttling: Rate exceeded\n\tstatus code: 400"),
+			want: true,
+		},
+		{
+			name: "SDK access denied",
+			err:  awserr.New("AccessDenied", "User is not authorized", nil),
+			want: false,
+		},
+		{
+			name: "provider error",
+			err:  errors.New("error reading S3 bucket: NoSuchBucket"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isThrottlingError(tt.err))
+		})
+	}
+}
+
+func TestReadClassifier(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &readClassifier{maxErrors: 2}
+	assert.Equal(retrier.Succeed, c.Classify(nil))
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(retrier.Retry, c.Classify(errors.New("Throttling: Rate exceeded")))
+	}
+
+	assert.Equal(retrier.Retry, c.Classify(errors.New("error")))
+	assert.Equal(retrier.Retry, c.Classify(errors.New("error")))
+	assert.Equal(retrier.Fail, c.Classify(errors.New("error")))
+}
@@ -24,9 +24,9 @@ type VPCSupplier struct {
 	vpcRunner              *terraform.ParallelResourceReader
 }
 
-func NewVPCSupplier(runner *parallel.ParallelRunner, client ec2iface.EC2API) *VPCSupplier {
+func NewVPCSupplier(reader terraform.ResourceReader, runner *parallel.ParallelRunner, client ec2iface.EC2API) *VPCSupplier {
 	return &VPCSupplier{
-		terraform.Provider(terraform.AWS),
+		reader,
 		awsdeserializer.NewDefaultVPCDeserializer(),
 		awsdeserializer.NewVPCDeserializer(),
 		client,
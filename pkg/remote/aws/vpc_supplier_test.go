@@ -88,7 +88,7 @@ func TestVPCSupplier_Resources(t *testing.T) {
 			}
 
 			terraform.AddProvider(terraform.AWS, provider)
-			resource.AddSupplier(NewVPCSupplier(provider.Runner(), ec2.New(provider.session)))
+			resource.AddSupplier(NewVPCSupplier(provider, provider.Runner(), ec2.New(provider.session)))
 		}
 
 		t.Run(c.test, func(tt *testing.T) {
@@ -20,10 +20,10 @@ func IsSupported(remote string) bool {
 	return false
 }
 
-func Activate(remote string, alerter *alerter.Alerter) error {
+func Activate(remote string, alerter *alerter.Alerter, options aws.Options) error {
 	switch remote {
 	case aws.RemoteAWSTerraform:
-		return aws.Init(alerter)
+		return aws.Init(alerter, options)
 	default:
 		return fmt.Errorf("unsupported remote '%s'", remote)
 	}
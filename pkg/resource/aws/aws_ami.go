@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsAmiResourceType = "aws_ami"
 
 type AwsAmi struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Architecture       *string           `cty:"architecture"`
 	Arn                *string           `cty:"arn" computed:"true"`
 	Description        *string           `cty:"description"`
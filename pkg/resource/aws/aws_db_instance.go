@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsDbInstanceResourceType = "aws_db_instance"
 
 type AwsDbInstance struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Address                            *string            `cty:"address" computed:"true"`
 	AllocatedStorage                   *int               `cty:"allocated_storage" computed:"true"`
 	AllowMajorVersionUpgrade           *bool              `cty:"allow_major_version_upgrade"`
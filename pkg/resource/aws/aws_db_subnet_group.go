@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsDbSubnetGroupResourceType = "aws_db_subnet_group"
 
 type AwsDbSubnetGroup struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn         *string           `cty:"arn" computed:"true"`
 	Description *string           `cty:"description"`
 	Id          string            `cty:"id" computed:"true"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsDefaultRouteTableResourceType = "aws_default_route_table"
 
 type AwsDefaultRouteTable struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	DefaultRouteTableId *string   `cty:"default_route_table_id"`
 	Id                  string    `cty:"id" computed:"true"`
 	OwnerId             *string   `cty:"owner_id" computed:"true"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsDefaultSecurityGroupResourceType = "aws_default_security_group"
 
 type AwsDefaultSecurityGroup struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn         *string `cty:"arn" computed:"true"`
 	Description *string `cty:"description" computed:"true"`
 	Egress      *[]struct {
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsDefaultSubnetResourceType = "aws_default_subnet"
 
 type AwsDefaultSubnet struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn                         *string           `cty:"arn" computed:"true"`
 	AssignIpv6AddressOnCreation *bool             `cty:"assign_ipv6_address_on_creation" computed:"true"`
 	AvailabilityZone            *string           `cty:"availability_zone"`
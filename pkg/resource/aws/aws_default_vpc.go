@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsDefaultVpcResourceType = "aws_default_vpc"
 
 type AwsDefaultVpc struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn                          *string           `cty:"arn" computed:"true"`
 	AssignGeneratedIpv6CidrBlock *bool             `cty:"assign_generated_ipv6_cidr_block" computed:"true"`
 	CidrBlock                    *string           `cty:"cidr_block" computed:"true"`
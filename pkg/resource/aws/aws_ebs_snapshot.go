@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsEbsSnapshotResourceType = "aws_ebs_snapshot"
 
 type AwsEbsSnapshot struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn                 *string           `cty:"arn" computed:"true"`
 	DataEncryptionKeyId *string           `cty:"data_encryption_key_id" computed:"true"`
 	Description         *string           `cty:"description"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsEbsVolumeResourceType = "aws_ebs_volume"
 
 type AwsEbsVolume struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn                *string           `cty:"arn" diff:"-" computed:"true"`
 	AvailabilityZone   *string           `cty:"availability_zone"`
 	Encrypted          *bool             `cty:"encrypted" computed:"true"`
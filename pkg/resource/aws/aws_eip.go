@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsEipResourceType = "aws_eip"
 
 type AwsEip struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	AllocationId           *string           `cty:"allocation_id" computed:"true"`
 	AssociateWithPrivateIp *string           `cty:"associate_with_private_ip"`
 	AssociationId          *string           `cty:"association_id" computed:"true"`
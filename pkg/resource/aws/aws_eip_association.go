@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsEipAssociationResourceType = "aws_eip_association"
 
 type AwsEipAssociation struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	AllocationId       *string `cty:"allocation_id" computed:"true"`
 	AllowReassociation *bool   `cty:"allow_reassociation"`
 	Id                 string  `cty:"id" computed:"true"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsIamAccessKeyResourceType = "aws_iam_access_key"
 
 type AwsIamAccessKey struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	EncryptedSecret   *string `cty:"encrypted_secret" computed:"true"`
 	Id                string  `cty:"id" computed:"true"`
 	KeyFingerprint    *string `cty:"key_fingerprint" computed:"true"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsIamPolicyAttachmentResourceType = "aws_iam_policy_attachment"
 
 type AwsIamPolicyAttachment struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Groups    []string `cty:"groups"`
 	Id        string   `cty:"id" diff:"Id, identifier" computed:"true"`
 	Name      *string  `cty:"name" diff:"-"`
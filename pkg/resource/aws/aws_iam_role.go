@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsIamRoleResourceType = "aws_iam_role"
 
 type AwsIamRole struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn                 *string           `cty:"arn" computed:"true"`
 	AssumeRolePolicy    *string           `cty:"assume_role_policy" jsonstring:"true"`
 	CreateDate          *string           `cty:"create_date" computed:"true"`
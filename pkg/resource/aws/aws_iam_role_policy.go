@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsIamRolePolicyResourceType = "aws_iam_role_policy"
 
 type AwsIamRolePolicy struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Id         string  `cty:"id" computed:"true"`
 	Name       *string `cty:"name" computed:"true"`
 	NamePrefix *string `cty:"name_prefix"`
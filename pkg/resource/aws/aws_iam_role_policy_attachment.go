@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsIamRolePolicyAttachmentResourceType = "aws_iam_role_policy_attachment"
 
 type AwsIamRolePolicyAttachment struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Id        string  `cty:"id" computed:"true"`
 	PolicyArn *string `cty:"policy_arn"`
 	Role      *string `cty:"role"`
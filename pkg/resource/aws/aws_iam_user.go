@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsIamUserResourceType = "aws_iam_user"
 
 type AwsIamUser struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn                 *string           `cty:"arn" computed:"true"`
 	ForceDestroy        *bool             `cty:"force_destroy" diff:"-"`
 	Id                  string            `cty:"id" computed:"true"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsIamUserPolicyResourceType = "aws_iam_user_policy"
 
 type AwsIamUserPolicy struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Id         string  `cty:"id" computed:"true"`
 	Name       *string `cty:"name" computed:"true"`
 	NamePrefix *string `cty:"name_prefix"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsIamUserPolicyAttachmentResourceType = "aws_iam_user_policy_attachment"
 
 type AwsIamUserPolicyAttachment struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Id        string  `cty:"id" computed:"true"`
 	PolicyArn *string `cty:"policy_arn"`
 	User      *string `cty:"user"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsInstanceResourceType = "aws_instance"
 
 type AwsInstance struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Ami                               *string           `cty:"ami"`
 	Arn                               *string           `cty:"arn" computed:"true"`
 	AssociatePublicIpAddress          *bool             `cty:"associate_public_ip_address" computed:"true"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsInternetGatewayResourceType = "aws_internet_gateway"
 
 type AwsInternetGateway struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn     *string           `cty:"arn" computed:"true"`
 	Id      string            `cty:"id" computed:"true"`
 	OwnerId *string           `cty:"owner_id" computed:"true"`
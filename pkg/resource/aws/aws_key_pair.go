@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsKeyPairResourceType = "aws_key_pair"
 
 type AwsKeyPair struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn           *string           `cty:"arn" computed:"true"`
 	Fingerprint   *string           `cty:"fingerprint" computed:"true"`
 	Id            string            `cty:"id" computed:"true"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsLambdaFunctionResourceType = "aws_lambda_function"
 
 type AwsLambdaFunction struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn                          *string           `cty:"arn" computed:"true"`
 	CodeSigningConfigArn         *string           `cty:"code_signing_config_arn"`
 	Description                  *string           `cty:"description"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsNatGatewayResourceType = "aws_nat_gateway"
 
 type AwsNatGateway struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	AllocationId       *string           `cty:"allocation_id"`
 	Id                 string            `cty:"id" computed:"true"`
 	NetworkInterfaceId *string           `cty:"network_interface_id" computed:"true"`
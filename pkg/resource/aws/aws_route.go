@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsRouteResourceType = "aws_route"
 
 type AwsRoute struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	DestinationCidrBlock     *string `cty:"destination_cidr_block"`
 	DestinationIpv6CidrBlock *string `cty:"destination_ipv6_cidr_block"`
 	DestinationPrefixListId  *string `cty:"destination_prefix_list_id" computed:"true"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsRoute53RecordResourceType = "aws_route53_record"
 
 type AwsRoute53Record struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	AllowOverwrite                *bool    `cty:"allow_overwrite" diff:"-" computed:"true"`
 	Fqdn                          *string  `cty:"fqdn" computed:"true"`
 	HealthCheckId                 *string  `cty:"health_check_id"`
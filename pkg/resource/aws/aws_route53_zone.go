@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsRoute53ZoneResourceType = "aws_route53_zone"
 
 type AwsRoute53Zone struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Comment         *string           `cty:"comment"`
 	DelegationSetId *string           `cty:"delegation_set_id"`
 	ForceDestroy    *bool             `cty:"force_destroy" diff:"-"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsRouteTableResourceType = "aws_route_table"
 
 type AwsRouteTable struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Id              string    `cty:"id" computed:"true"`
 	OwnerId         *string   `cty:"owner_id" computed:"true"`
 	PropagatingVgws *[]string `cty:"propagating_vgws" computed:"true"` // Could be null in state
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsRouteTableAssociationResourceType = "aws_route_table_association"
 
 type AwsRouteTableAssociation struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	GatewayId    *string `cty:"gateway_id"`
 	Id           string  `cty:"id" computed:"true"`
 	RouteTableId *string `cty:"route_table_id"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsS3BucketResourceType = "aws_s3_bucket"
 
 type AwsS3Bucket struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	AccelerationStatus       *string           `cty:"acceleration_status" computed:"true"`
 	Acl                      *string           `cty:"acl" diff:"-"`
 	Arn                      *string           `cty:"arn" computed:"true"`
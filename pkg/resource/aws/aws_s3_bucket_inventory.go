@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsS3BucketInventoryResourceType = "aws_s3_bucket_inventory"
 
 type AwsS3BucketInventory struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Bucket                 *string  `cty:"bucket"`
 	Enabled                *bool    `cty:"enabled"`
 	Id                     string   `cty:"id" computed:"true"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsS3BucketMetricResourceType = "aws_s3_bucket_metric"
 
 type AwsS3BucketMetric struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Bucket *string `cty:"bucket"`
 	Id     string  `cty:"id" computed:"true"`
 	Name   *string `cty:"name"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsS3BucketPolicyResourceType = "aws_s3_bucket_policy"
 
 type AwsS3BucketPolicy struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Bucket *string `cty:"bucket" diff:"-"`
 	Id     string  `cty:"id" diff:"-" computed:"true"`
 	Policy *string `cty:"policy" jsonstring:"true"`
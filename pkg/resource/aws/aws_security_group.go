@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsSecurityGroupResourceType = "aws_security_group"
 
 type AwsSecurityGroup struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn         *string `cty:"arn" computed:"true"`
 	Description *string `cty:"description"`
 	Egress      *[]struct {
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsSecurityGroupRuleResourceType = "aws_security_group_rule"
 
 type AwsSecurityGroupRule struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	CidrBlocks            *[]string `cty:"cidr_blocks"`
 	Description           *string   `cty:"description"`
 	FromPort              *int      `cty:"from_port"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsSubnetResourceType = "aws_subnet"
 
 type AwsSubnet struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn                         *string           `cty:"arn" computed:"true"`
 	AssignIpv6AddressOnCreation *bool             `cty:"assign_ipv6_address_on_creation"`
 	AvailabilityZone            *string           `cty:"availability_zone" computed:"true"`
@@ -1,9 +1,13 @@
 // GENERATED, DO NOT EDIT THIS FILE
 package aws
 
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
 const AwsVpcResourceType = "aws_vpc"
 
 type AwsVpc struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Arn                          *string           `cty:"arn" computed:"true"`
 	AssignGeneratedIpv6CidrBlock *bool             `cty:"assign_generated_ipv6_cidr_block"`
 	CidrBlock                    *string           `cty:"cidr_block"`
@@ -0,0 +1,69 @@
+package aws
+
+import (
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Resources returns an empty instance of every supported resource
+func Resources() []resource.Resource {
+	return []resource.Resource{
+		&AwsAmi{},
+		&AwsDbInstance{},
+		&AwsDbSubnetGroup{},
+		&AwsDefaultRouteTable{},
+		&AwsDefaultSecurityGroup{},
+		&AwsDefaultSubnet{},
+		&AwsDefaultVpc{},
+		&AwsEbsSnapshot{},
+		&AwsEbsVolume{},
+		&AwsEip{},
+		&AwsEipAssociation{},
+		&AwsIamAccessKey{},
+		&AwsIamPolicy{},
+		&AwsIamPolicyAttachment{},
+		&AwsIamRole{},
+		&AwsIamRolePolicy{},
+		&AwsIamRolePolicyAttachment{},
+		&AwsIamUser{},
+		&AwsIamUserPolicy{},
+		&AwsIamUserPolicyAttachment{},
+		&AwsInstance{},
+		&AwsInternetGateway{},
+		&AwsKeyPair{},
+		&AwsLambdaFunction{},
+		&AwsNatGateway{},
+		&AwsRoute{},
+		&AwsRoute53Record{},
+		&AwsRoute53Zone{},
+		&AwsRouteTable{},
+		&AwsRouteTableAssociation{},
+		&AwsS3Bucket{},
+		&AwsS3BucketAnalyticsConfiguration{},
+		&AwsS3BucketInventory{},
+		&AwsS3BucketMetric{},
+		&AwsS3BucketNotification{},
+		&AwsS3BucketPolicy{},
+		&AwsSecurityGroup{},
+		&AwsSecurityGroupRule{},
+		&AwsSubnet{},
+		&AwsVpc{},
+	}
+}
+
+var resourcesByType = func() map[string]resource.Resource {
+	resources := make(map[string]resource.Resource)
+	for _, res := range Resources() {
+		resources[res.TerraformType()] = res
+	}
+	return resources
+}()
+
+// ConformValue drops attributes of a value read with the provider schema that the resource of the given type does not hold
+func ConformValue(ty string, val cty.Value) cty.Value {
+	res, exists := resourcesByType[ty]
+	if !exists {
+		return val
+	}
+	return resource.ConformValue(val, res)
+}
@@ -0,0 +1,16 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// Generated resources must embed resource.Metadata, resources generated without it would not be attributed
+// their source, region and account
+func TestResources_EmbedMetadata(t *testing.T) {
+	for _, res := range Resources() {
+		assert.NotNil(t, resource.GetMetadata(res), "%s does not embed resource.Metadata", res.TerraformType())
+	}
+}
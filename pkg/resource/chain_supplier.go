@@ -12,9 +12,13 @@ type ChainSupplier struct {
 	runner    *parallel.ParallelRunner
 }
 
-func NewChainSupplier() *ChainSupplier {
+// NewChainSupplier returns a supplier running at most maxRun suppliers at once, as many as CPUs when maxRun is 0
+func NewChainSupplier(maxRun int) *ChainSupplier {
+	if maxRun <= 0 {
+		maxRun = runtime.NumCPU()
+	}
 	return &ChainSupplier{
-		runner: parallel.NewParallelRunner(context.TODO(), int64(runtime.NumCPU())),
+		runner: parallel.NewParallelRunner(context.TODO(), int64(maxRun)),
 	}
 }
 
@@ -40,7 +40,7 @@ func TestChainSupplier_Resources(t *testing.T) {
 		nil,
 	).Once()
 
-	chain := resource.NewChainSupplier()
+	chain := resource.NewChainSupplier(0)
 	chain.AddSupplier(&fakeTestSupplier)
 	chain.AddSupplier(&anotherFakeTestSupplier)
 
@@ -79,7 +79,7 @@ func TestChainSupplier_Resources_WithError(t *testing.T) {
 		Return(nil, errors.New("error from another supplier")).
 		Once()
 
-	chain := resource.NewChainSupplier()
+	chain := resource.NewChainSupplier(0)
 	chain.AddSupplier(&fakeTestSupplier)
 	chain.AddSupplier(&anotherFakeTestSupplier)
 
@@ -0,0 +1,93 @@
+package resource
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+var availabilityZoneRegion = regexp.MustCompile(`^([a-z]{2}(-gov)?-[a-z]+-[0-9]+)`)
+
+// Metadata holds informations about a resource that are not part of its attributes,
+// like where it has been found. It is embedded in resources and never compared.
+type Metadata struct {
+	Source  *Source `json:"source,omitempty"`
+	Region  string  `json:"region,omitempty"`  // Region the resource has been found in, empty for global resources
+	Account string  `json:"account,omitempty"` // ID of the account the resource belongs to, only set when scanning several accounts
+}
+
+// Source describes where a resource has been read in IaC
+type Source struct {
+	State     string `json:"state"`               // IaC source the resource has been read from (e.g. tfstate+s3://bucket/terraform.tfstate)
+	Address   string `json:"address"`             // Full address of the resource in state (e.g. module.logs.aws_s3_bucket.logs["eu"])
+	Module    string `json:"module,omitempty"`    // Module path of the resource, empty for root module (e.g. module.vpc.module.subnets)
+	Workspace string `json:"workspace,omitempty"` // Terraform workspace of the state, empty when workspaces are not scanned
+}
+
+func (s Source) String() string {
+	if s.Workspace != "" {
+		return fmt.Sprintf("%s in %s (workspace %s)", s.Address, s.State, s.Workspace)
+	}
+	return fmt.Sprintf("%s in %s", s.Address, s.State)
+}
+
+// RegionFromValue derives the region of a resource read in IaC from its ARN or its availability zone,
+// it is empty for global resources and resources holding none of these attributes
+func RegionFromValue(val cty.Value) string {
+	if val.IsNull() || !val.IsKnown() || !val.Type().IsObjectType() {
+		return ""
+	}
+	if arn := stringAttr(val, "arn"); arn != "" {
+		// arn:partition:service:region:account-id:resource, region is empty for global services
+		parts := strings.SplitN(arn, ":", 5)
+		if len(parts) == 5 {
+			return parts[3]
+		}
+		return ""
+	}
+	if az := stringAttr(val, "availability_zone"); az != "" {
+		return availabilityZoneRegion.FindString(az)
+	}
+	return ""
+}
+
+func stringAttr(val cty.Value, name string) string {
+	if !val.Type().HasAttribute(name) {
+		return ""
+	}
+	attr := val.GetAttr(name)
+	if attr.Type() != cty.String || attr.IsNull() || !attr.IsKnown() {
+		return ""
+	}
+	return attr.AsString()
+}
+
+// ResourceWithMetadata is implemented by every resource embedding Metadata
+type ResourceWithMetadata interface {
+	Resource
+	Meta() *Metadata
+}
+
+func (m *Metadata) Meta() *Metadata {
+	return m
+}
+
+// GetMetadata returns metadata of a resource, or nil if the resource does not carry any
+func GetMetadata(res Resource) *Metadata {
+	r, ok := res.(ResourceWithMetadata)
+	if !ok {
+		return nil
+	}
+	return r.Meta()
+}
+
+// GetSource returns where a resource has been read in IaC, or nil if it does not come from IaC
+func GetSource(res Resource) *Source {
+	meta := GetMetadata(res)
+	if meta == nil {
+		return nil
+	}
+	return meta.Source
+}
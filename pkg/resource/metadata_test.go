@@ -0,0 +1,58 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestRegionFromValue(t *testing.T) {
+	tests := []struct {
+		name string
+		val  cty.Value
+		want string
+	}{
+		{
+			name: "regional ARN",
+			val:  cty.ObjectVal(map[string]cty.Value{"arn": cty.StringVal("arn:aws:lambda:eu-west-3:123456789012:function:foo")}),
+			want: "eu-west-3",
+		},
+		{
+			name: "global ARN",
+			val:  cty.ObjectVal(map[string]cty.Value{"arn": cty.StringVal("arn:aws:iam::123456789012:user/foo")}),
+			want: "",
+		},
+		{
+			name: "ARN takes precedence over availability zone",
+			val: cty.ObjectVal(map[string]cty.Value{
+				"arn":               cty.StringVal("arn:aws:rds:us-east-1:123456789012:db:foo"),
+				"availability_zone": cty.StringVal("eu-west-3a"),
+			}),
+			want: "us-east-1",
+		},
+		{
+			name: "availability zone",
+			val: cty.ObjectVal(map[string]cty.Value{
+				"arn":               cty.NullVal(cty.String),
+				"availability_zone": cty.StringVal("us-gov-west-1b"),
+			}),
+			want: "us-gov-west-1",
+		},
+		{
+			name: "no attribute",
+			val:  cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("foo")}),
+			want: "",
+		},
+		{
+			name: "null value",
+			val:  cty.NullVal(cty.Object(map[string]cty.Type{"arn": cty.String})),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RegionFromValue(tt.val))
+		})
+	}
+}
@@ -16,6 +16,7 @@ type SerializableResource struct {
 type SerializedResource struct {
 	Id   string `json:"id"`
 	Type string `json:"type"`
+	Metadata
 }
 
 func (u SerializedResource) TerraformId() string {
@@ -26,6 +27,11 @@ func (u SerializedResource) TerraformType() string {
 	return u.Type
 }
 
+// Meta returns a copy of the resource metadata as a SerializedResource is not meant to be updated
+func (u SerializedResource) Meta() *Metadata {
+	return &u.Metadata
+}
+
 func (s *SerializableResource) UnmarshalJSON(bytes []byte) error {
 	var res SerializedResource
 
@@ -37,7 +43,11 @@ func (s *SerializableResource) UnmarshalJSON(bytes []byte) error {
 }
 
 func (s SerializableResource) MarshalJSON() ([]byte, error) {
-	return json.Marshal(SerializedResource{Id: s.TerraformId(), Type: s.TerraformType()})
+	res := SerializedResource{Id: s.TerraformId(), Type: s.TerraformType()}
+	if meta := GetMetadata(s.Resource); meta != nil {
+		res.Metadata = *meta
+	}
+	return json.Marshal(res)
 }
 
 type NormalizedResource interface {
@@ -0,0 +1,126 @@
+package resource
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Resources are generated from the schema of a given provider version, other versions of the provider
+// may have attributes the resource struct cannot hold. These attributes are dropped before decoding values.
+
+// UnsupportedAttributes returns paths of the attributes of a provider type that the resource does not hold
+func UnsupportedAttributes(ty cty.Type, res Resource) []string {
+	unsupported := make([]string, 0)
+	conformType(ty, reflect.TypeOf(res), "", &unsupported)
+	sort.Strings(unsupported)
+	return unsupported
+}
+
+// ConformValue drops attributes of a value read with a provider schema that the resource does not hold
+func ConformValue(val cty.Value, res Resource) cty.Value {
+	return conformValue(val, reflect.TypeOf(res))
+}
+
+func conformType(ty cty.Type, typ reflect.Type, path string, unsupported *[]string) cty.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch {
+	case ty.IsObjectType() && typ.Kind() == reflect.Struct:
+		fields := ctyFields(typ)
+		attributes := make(map[string]cty.Type)
+		for name, attrType := range ty.AttributeTypes() {
+			attrPath := name
+			if path != "" {
+				attrPath = strings.Join([]string{path, name}, ".")
+			}
+			field, exists := fields[name]
+			if !exists {
+				if unsupported != nil {
+					*unsupported = append(*unsupported, attrPath)
+				}
+				continue
+			}
+			attributes[name] = conformType(attrType, field.Type, attrPath, unsupported)
+		}
+		return cty.Object(attributes)
+	case ty.IsListType() && typ.Kind() == reflect.Slice:
+		return cty.List(conformType(ty.ElementType(), typ.Elem(), path, unsupported))
+	case ty.IsSetType() && typ.Kind() == reflect.Slice:
+		return cty.Set(conformType(ty.ElementType(), typ.Elem(), path, unsupported))
+	case ty.IsMapType() && typ.Kind() == reflect.Map:
+		return cty.Map(conformType(ty.ElementType(), typ.Elem(), path, unsupported))
+	}
+	return ty
+}
+
+func conformValue(val cty.Value, typ reflect.Type) cty.Value {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	ty := val.Type()
+	if val.IsNull() {
+		return cty.NullVal(conformType(ty, typ, "", nil))
+	}
+	if !val.IsKnown() {
+		return cty.UnknownVal(conformType(ty, typ, "", nil))
+	}
+
+	switch {
+	case ty.IsObjectType() && typ.Kind() == reflect.Struct:
+		fields := ctyFields(typ)
+		attributes := make(map[string]cty.Value)
+		for name, attr := range val.AsValueMap() {
+			field, exists := fields[name]
+			if !exists {
+				continue
+			}
+			attributes[name] = conformValue(attr, field.Type)
+		}
+		return cty.ObjectVal(attributes)
+	case (ty.IsListType() || ty.IsSetType()) && typ.Kind() == reflect.Slice:
+		elemType := conformType(ty.ElementType(), typ.Elem(), "", nil)
+		if val.LengthInt() == 0 {
+			if ty.IsSetType() {
+				return cty.SetValEmpty(elemType)
+			}
+			return cty.ListValEmpty(elemType)
+		}
+		elems := make([]cty.Value, 0, val.LengthInt())
+		for _, elem := range val.AsValueSlice() {
+			elems = append(elems, conformValue(elem, typ.Elem()))
+		}
+		if ty.IsSetType() {
+			return cty.SetVal(elems)
+		}
+		return cty.ListVal(elems)
+	case ty.IsMapType() && typ.Kind() == reflect.Map:
+		elemType := conformType(ty.ElementType(), typ.Elem(), "", nil)
+		if val.LengthInt() == 0 {
+			return cty.MapValEmpty(elemType)
+		}
+		elems := make(map[string]cty.Value, val.LengthInt())
+		for key, elem := range val.AsValueMap() {
+			elems[key] = conformValue(elem, typ.Elem())
+		}
+		return cty.MapVal(elems)
+	}
+	return val
+}
+
+// ctyFields returns fields of a struct by the name of their cty tag
+func ctyFields(typ reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if name := field.Tag.Get("cty"); name != "" {
+			fields[name] = field
+		}
+	}
+	return fields
+}
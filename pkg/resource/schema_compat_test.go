@@ -0,0 +1,93 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type compatResource struct {
+	Metadata `json:"-" diff:"-"`
+
+	Id      string             `cty:"id"`
+	Tags    map[string]string  `cty:"tags"`
+	Rules   *[]compatRule      `cty:"rule"`
+	Options *[]struct{}        `cty:"options"`
+	Nested  *map[string]string `cty:"nested"`
+}
+
+type compatRule struct {
+	Port *int `cty:"port"`
+}
+
+func (r *compatResource) TerraformId() string {
+	return r.Id
+}
+
+func (r *compatResource) TerraformType() string {
+	return "compat_resource"
+}
+
+var compatProviderType = cty.Object(map[string]cty.Type{
+	"id":   cty.String,
+	"arn":  cty.String,
+	"tags": cty.Map(cty.String),
+	"rule": cty.List(cty.Object(map[string]cty.Type{
+		"port":     cty.Number,
+		"protocol": cty.String,
+	})),
+	"options": cty.List(cty.Object(map[string]cty.Type{
+		"enabled": cty.Bool,
+	})),
+	"nested": cty.Map(cty.String),
+})
+
+func TestUnsupportedAttributes(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{"arn", "options.enabled", "rule.protocol"},
+		UnsupportedAttributes(compatProviderType, &compatResource{}),
+	)
+}
+
+func TestConformValue(t *testing.T) {
+	val := cty.ObjectVal(map[string]cty.Value{
+		"id":   cty.StringVal("foo"),
+		"arn":  cty.StringVal("arn:foo"),
+		"tags": cty.MapValEmpty(cty.String),
+		"rule": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"port":     cty.NumberIntVal(80),
+				"protocol": cty.StringVal("tcp"),
+			}),
+		}),
+		"options": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"enabled": cty.True,
+			}),
+		}),
+		"nested": cty.NullVal(cty.Map(cty.String)),
+	})
+
+	got := ConformValue(val, &compatResource{})
+
+	assert.Equal(t, cty.ObjectVal(map[string]cty.Value{
+		"id":   cty.StringVal("foo"),
+		"tags": cty.MapValEmpty(cty.String),
+		"rule": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"port": cty.NumberIntVal(80),
+			}),
+		}),
+		"options": cty.ListVal([]cty.Value{cty.EmptyObjectVal}),
+		"nested":  cty.NullVal(cty.Map(cty.String)),
+	}), got)
+
+	nullRules := ConformValue(cty.ObjectVal(map[string]cty.Value{
+		"id":   cty.StringVal("foo"),
+		"rule": cty.NullVal(compatProviderType.AttributeType("rule")),
+	}), &compatResource{})
+	assert.True(t, nullRules.GetAttr("rule").IsNull())
+	assert.Equal(t, cty.List(cty.Object(map[string]cty.Type{"port": cty.Number})), nullRules.GetAttr("rule").Type())
+}
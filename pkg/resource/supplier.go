@@ -1,5 +1,10 @@
 package resource
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Resource Supplier supply the list of resource.Resource, its the front to retrieve remote resources
 type Supplier interface {
 	Resources() ([]Resource, error)
@@ -9,3 +14,42 @@ type StoppableSupplier interface {
 	Supplier
 	Stop()
 }
+
+// SupplierError is returned by a supplier unable to list resources of some types,
+// the scan goes on without these types instead of failing
+type SupplierError struct {
+	Err           error
+	ResourceTypes []string
+	Region        string // Region the supplier was scanning, empty for global resources
+	Account       string // Account the supplier was scanning, only set when scanning several accounts
+}
+
+func (e *SupplierError) Error() string {
+	return fmt.Sprintf("unable to list %s: %s", strings.Join(e.ResourceTypes, ", "), e.Err)
+}
+
+func (e *SupplierError) Unwrap() error {
+	return e.Err
+}
+
+// TypedSupplier wraps errors of a supplier in a SupplierError holding the types of resources it lists
+type TypedSupplier struct {
+	supplier      Supplier
+	resourceTypes []string
+}
+
+func NewTypedSupplier(supplier Supplier, resourceTypes ...string) *TypedSupplier {
+	return &TypedSupplier{supplier, resourceTypes}
+}
+
+func (s TypedSupplier) String() string {
+	return strings.Join(s.resourceTypes, ", ")
+}
+
+func (s TypedSupplier) Resources() ([]Resource, error) {
+	resources, err := s.supplier.Resources()
+	if err != nil {
+		return nil, &SupplierError{Err: err, ResourceTypes: s.resourceTypes}
+	}
+	return resources, nil
+}
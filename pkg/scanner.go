@@ -2,9 +2,11 @@ package pkg
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/cloudskiff/driftctl/pkg/parallel"
+	"github.com/cloudskiff/driftctl/pkg/progress"
 	"github.com/sirupsen/logrus"
 
 	"github.com/cloudskiff/driftctl/pkg/alerter"
@@ -17,10 +19,11 @@ type Scanner struct {
 	alerter           *alerter.Alerter
 }
 
-func NewScanner(resourceSuppliers []resource.Supplier, alerter *alerter.Alerter) *Scanner {
+// NewScanner returns a scanner running at most parallelism suppliers at once
+func NewScanner(resourceSuppliers []resource.Supplier, alerter *alerter.Alerter, parallelism int) *Scanner {
 	return &Scanner{
 		resourceSuppliers: resourceSuppliers,
-		runner:            parallel.NewParallelRunner(context.TODO(), 10),
+		runner:            parallel.NewParallelRunner(context.TODO(), int64(parallelism)),
 		alerter:           alerter,
 	}
 }
@@ -28,10 +31,19 @@ func NewScanner(resourceSuppliers []resource.Supplier, alerter *alerter.Alerter)
 func (s *Scanner) Resources() ([]resource.Resource, error) {
 	for _, resourceProvider := range s.resourceSuppliers {
 		supplier := resourceProvider
+		name := supplierName(supplier)
 		s.runner.Run(func() (interface{}, error) {
+			progress.Report(progress.Event{Type: progress.SupplierListing, Supplier: name})
 			res, err := supplier.Resources()
 			if err != nil {
-				return nil, err
+				progress.Report(progress.Event{Type: progress.SupplierFailed, Supplier: name, Err: err})
+				var supplierErr *resource.SupplierError
+				if !errors.As(err, &supplierErr) {
+					return nil, err
+				}
+				// Other suppliers go on, resources of failed types are left out of the analysis
+				s.alertScanFailure(supplierErr)
+				return []resource.Resource{}, nil
 			}
 			for _, resource := range res {
 				logrus.WithFields(logrus.Fields{
@@ -39,6 +51,7 @@ func (s *Scanner) Resources() ([]resource.Resource, error) {
 					"type": resource.TerraformType(),
 				}).Debug("Found cloud resource")
 			}
+			progress.Report(progress.Event{Type: progress.SupplierDone, Supplier: name, Count: len(res)})
 			return res, nil
 		})
 	}
@@ -77,6 +90,42 @@ loop:
 	return results, s.runner.Err()
 }
 
+// supplierName describes suppliers in progress events, e.g. aws_instance in eu-west-3
+func supplierName(supplier resource.Supplier) string {
+	if stringer, ok := supplier.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprintf("%T", supplier)
+}
+
+func (s *Scanner) alertScanFailure(err *resource.SupplierError) {
+	logrus.WithFields(logrus.Fields{
+		"types":   err.ResourceTypes,
+		"region":  err.Region,
+		"account": err.Account,
+	}).Debugf("Unable to list resources: %s", err.Err)
+
+	location := ""
+	if err.Region != "" {
+		location += fmt.Sprintf(" in region %s", err.Region)
+	}
+	if err.Account != "" {
+		location += fmt.Sprintf(" of account %s", err.Account)
+	}
+	for _, ty := range err.ResourceTypes {
+		s.alerter.SendAlert(ty, alerter.Alert{
+			Message: fmt.Sprintf("Unable to list %s%s, these resources are not analyzed: %s", ty, location, err.Err),
+			// Resources of this type are only ignored in the failed region and account, see ScanFailure.Covers
+			ScanFailure: &alerter.ScanFailure{
+				ResourceType: ty,
+				Region:       err.Region,
+				Account:      err.Account,
+				Error:        err.Err.Error(),
+			},
+		})
+	}
+}
+
 func (s *Scanner) Stop() {
 	logrus.Debug("Stopping scanner")
 	s.runner.Stop(fmt.Errorf("interrupted"))
@@ -0,0 +1,123 @@
+package pkg
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/mocks"
+	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/parallel"
+	"github.com/cloudskiff/driftctl/pkg/progress"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	testresource "github.com/cloudskiff/driftctl/test/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanner_Resources_WithFailingSupplier(t *testing.T) {
+	assert := assert.New(t)
+
+	fakeSupplier := mocks.Supplier{}
+	fakeSupplier.On("Resources").Return(
+		[]resource.Resource{
+			testresource.FakeResource{
+				Id:   "fake-resource",
+				Type: "FakeResource",
+			},
+		},
+		nil,
+	).Once()
+
+	failingSupplier := mocks.Supplier{}
+	failingSupplier.On("Resources").Return(nil, errors.New("AccessDenied")).Once()
+
+	typedSupplier := resource.NewTypedSupplier(&failingSupplier, "aws_s3_bucket", "aws_s3_bucket_policy")
+
+	alerts := alerter.NewAlerter()
+	scanner := NewScanner([]resource.Supplier{&fakeSupplier, typedSupplier}, alerts, parallel.DefaultMaxRun)
+
+	res, err := scanner.Resources()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fakeSupplier.AssertExpectations(t)
+	failingSupplier.AssertExpectations(t)
+	assert.Len(res, 1)
+	assert.Equal(alerter.Alerts{
+		"aws_s3_bucket": []alerter.Alert{
+			{
+				Message: "Unable to list aws_s3_bucket, these resources are not analyzed: AccessDenied",
+				ScanFailure: &alerter.ScanFailure{
+					ResourceType: "aws_s3_bucket",
+					Error:        "AccessDenied",
+				},
+			},
+		},
+		"aws_s3_bucket_policy": []alerter.Alert{
+			{
+				Message: "Unable to list aws_s3_bucket_policy, these resources are not analyzed: AccessDenied",
+				ScanFailure: &alerter.ScanFailure{
+					ResourceType: "aws_s3_bucket_policy",
+					Error:        "AccessDenied",
+				},
+			},
+		},
+	}, alerts.Retrieve())
+}
+
+func TestScanner_Resources_WithUntypedError(t *testing.T) {
+	failingSupplier := mocks.Supplier{}
+	failingSupplier.On("Resources").Return(nil, errors.New("unexpected error")).Once()
+
+	scanner := NewScanner([]resource.Supplier{&failingSupplier}, alerter.NewAlerter(), parallel.DefaultMaxRun)
+
+	_, err := scanner.Resources()
+	assert.EqualError(t, err, "unexpected error")
+}
+
+type fakeReporter struct {
+	lock   sync.Mutex
+	events []progress.Event
+}
+
+func (r *fakeReporter) Report(event progress.Event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = append(r.events, event)
+}
+
+func TestScanner_Resources_Progress(t *testing.T) {
+	fakeSupplier := mocks.Supplier{}
+	fakeSupplier.On("Resources").Return(
+		[]resource.Resource{
+			testresource.FakeResource{Id: "bucket", Type: "aws_s3_bucket"},
+		},
+		nil,
+	).Once()
+
+	failingSupplier := mocks.Supplier{}
+	failingSupplier.On("Resources").Return(nil, errors.New("AccessDenied")).Once()
+
+	reporter := &fakeReporter{}
+	progress.AddReporter(reporter)
+	defer progress.RemoveReporter(reporter)
+
+	scanner := NewScanner([]resource.Supplier{
+		resource.NewTypedSupplier(&fakeSupplier, "aws_s3_bucket"),
+		resource.NewTypedSupplier(&failingSupplier, "aws_iam_user"),
+	}, alerter.NewAlerter(), parallel.DefaultMaxRun)
+	if _, err := scanner.Resources(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.ElementsMatch(t, []progress.Event{
+		{Type: progress.SupplierListing, Supplier: "aws_s3_bucket"},
+		{Type: progress.SupplierDone, Supplier: "aws_s3_bucket", Count: 1},
+		{Type: progress.SupplierListing, Supplier: "aws_iam_user"},
+		{Type: progress.SupplierFailed, Supplier: "aws_iam_user", Err: &resource.SupplierError{
+			Err:           errors.New("AccessDenied"),
+			ResourceTypes: []string{"aws_iam_user"},
+		}},
+	}, reporter.events)
+}
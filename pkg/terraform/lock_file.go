@@ -0,0 +1,62 @@
+package terraform
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+)
+
+// DefaultLockFile is the dependency lock file written by terraform init in the working directory
+const DefaultLockFile = ".terraform.lock.hcl"
+
+type lockFile struct {
+	Providers []lockedProvider `hcl:"provider,block"`
+}
+
+type lockedProvider struct {
+	Address string   `hcl:"address,label"`
+	Version string   `hcl:"version"`
+	Hashes  []string `hcl:"hashes,optional"`
+	Remain  hcl.Body `hcl:",remain"`
+}
+
+// ProviderVersionFromLockFile returns the version of a provider of the hashicorp namespace
+// locked in a dependency lock file, or an empty string when the provider is not locked
+func ProviderVersionFromLockFile(path, name string) (string, error) {
+	provider, err := readLockedProvider(path, name)
+	if err != nil || provider == nil {
+		return "", err
+	}
+	return provider.Version, nil
+}
+
+// ProviderHashesFromLockFile returns the hashes (h1: and zh:) of a provider of the hashicorp namespace
+// locked in a dependency lock file, or nil when the provider is not locked
+func ProviderHashesFromLockFile(path, name string) ([]string, error) {
+	provider, err := readLockedProvider(path, name)
+	if err != nil || provider == nil {
+		return nil, err
+	}
+	return provider.Hashes, nil
+}
+
+func readLockedProvider(path, name string) (*lockedProvider, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("unable to read lock file %s: %w", path, err)
+	}
+
+	var file lockFile
+	if err := hclsimple.DecodeFile(path, nil, &file); err != nil {
+		return nil, fmt.Errorf("unable to read lock file %s: %w", path, err)
+	}
+
+	address := fmt.Sprintf("registry.terraform.io/hashicorp/%s", name)
+	for _, provider := range file.Providers {
+		if provider.Address == address {
+			return &provider, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,64 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderVersionFromLockFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "locked provider",
+			path: "testdata/lockfile/.terraform.lock.hcl",
+			want: "3.30.0",
+		},
+		{
+			name: "provider not locked",
+			path: "testdata/lockfile/no_aws.terraform.lock.hcl",
+			want: "",
+		},
+		{
+			name:    "missing lock file",
+			path:    "testdata/lockfile/missing.hcl",
+			wantErr: true,
+		},
+		{
+			name:    "invalid lock file",
+			path:    "testdata/invalid.zip",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ProviderVersionFromLockFile(tt.path, "aws")
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProviderHashesFromLockFile(t *testing.T) {
+	got, err := ProviderHashesFromLockFile("testdata/lockfile/.terraform.lock.hcl", "aws")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"h1:z7gLKpNN4FAYvBqQ5pnGHi1eWX0hmn/nFn4EVYROSz8=",
+		"zh:01f562a6a31fe46a8ca74804f360e3452b26f71abc549ce1f0ab5a8af2484cdf",
+	}, got)
+
+	got, err = ProviderHashesFromLockFile("testdata/lockfile/no_aws.terraform.lock.hcl", "aws")
+	assert.Nil(t, err)
+	assert.Nil(t, got)
+
+	_, err = ProviderHashesFromLockFile("testdata/lockfile/missing.hcl", "aws")
+	assert.NotNil(t, err)
+}
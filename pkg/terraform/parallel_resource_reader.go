@@ -2,6 +2,7 @@ package terraform
 
 import (
 	"github.com/cloudskiff/driftctl/pkg/parallel"
+	"github.com/cloudskiff/driftctl/pkg/progress"
 
 	"github.com/zclconf/go-cty/cty"
 )
@@ -37,7 +38,9 @@ Loop:
 }
 
 func (p *ParallelResourceReader) Run(runnable func() (cty.Value, error)) {
+	progress.Report(progress.Event{Type: progress.ResourceQueued, Supplier: p.runner.Name()})
 	p.runner.Run(func() (interface{}, error) {
+		defer progress.Report(progress.Event{Type: progress.ResourceRead, Supplier: p.runner.Name()})
 		return runnable()
 	})
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/cloudskiff/driftctl/pkg/parallel"
+	"github.com/cloudskiff/driftctl/pkg/progress"
 	"github.com/r3labs/diff/v2"
 
 	"github.com/stretchr/testify/assert"
@@ -77,3 +79,37 @@ func TestParallelResourceReader_Wait(t *testing.T) {
 		})
 	}
 }
+
+type countingReporter struct {
+	lock   sync.Mutex
+	counts map[progress.Event]int
+}
+
+func (r *countingReporter) Report(event progress.Event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.counts[event]++
+}
+
+func TestParallelResourceReader_Progress(t *testing.T) {
+	reporter := &countingReporter{counts: make(map[progress.Event]int)}
+	progress.AddReporter(reporter)
+	defer progress.RemoveReporter(reporter)
+
+	runner := parallel.NewParallelRunner(context.TODO(), 10)
+	runner.SetName("aws_s3_bucket")
+	p := NewParallelResourceReader(runner.SubRunner())
+	for i := 0; i < 3; i++ {
+		p.Run(func() (cty.Value, error) {
+			return cty.StringVal("bucket"), nil
+		})
+	}
+	if _, err := p.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, map[progress.Event]int{
+		{Type: progress.ResourceQueued, Supplier: "aws_s3_bucket"}: 3,
+		{Type: progress.ResourceRead, Supplier: "aws_s3_bucket"}:   3,
+	}, reporter.counts)
+}
@@ -1,50 +1,106 @@
 package terraform
 
 import (
+	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"runtime"
+	"strings"
 
 	"github.com/hashicorp/go-getter"
 
 	"github.com/sirupsen/logrus"
 )
 
+// DefaultProviderMirrorURL is the base URL providers are downloaded from by default
+const DefaultProviderMirrorURL = "https://releases.hashicorp.com"
+
 type ProviderDownloaderInterface interface {
-	Download(url, path string) error
+	Download(url, path, checksum string) error
 	GetProviderUrl(name, version string) string
+	GetProviderChecksum(name, version string) (string, error)
 }
 
 type ProviderDownloader struct {
 	httpclient *http.Client
 	unzip      getter.ZipDecompressor
 	context    context.Context
+	mirrorURL  string
 }
 
-func NewProviderDownloader() *ProviderDownloader {
+// NewProviderDownloader creates a downloader of providers published on the given mirror,
+// following the layout of releases.hashicorp.com (used when mirrorURL is empty)
+func NewProviderDownloader(mirrorURL string) *ProviderDownloader {
+	if mirrorURL == "" {
+		mirrorURL = DefaultProviderMirrorURL
+	}
 	return &ProviderDownloader{
 		httpclient: http.DefaultClient,
 		unzip:      getter.ZipDecompressor{},
 		context:    context.Background(),
+		mirrorURL:  strings.TrimSuffix(mirrorURL, "/"),
 	}
 }
 
 func (p *ProviderDownloader) GetProviderUrl(name, version string) string {
 	return fmt.Sprintf(
-		"https://releases.hashicorp.com/terraform-provider-%s/%s/terraform-provider-%s_%s_%s_%s.zip",
+		"%s/terraform-provider-%s/%s/%s",
+		p.mirrorURL,
+		name,
+		version,
+		providerArchiveName(name, version),
+	)
+}
+
+// GetProviderChecksum returns the SHA256 of the provider archive published in the SHA256SUMS file of the release
+func (p *ProviderDownloader) GetProviderChecksum(name, version string) (string, error) {
+	url := fmt.Sprintf(
+		"%s/terraform-provider-%s/%s/terraform-provider-%s_%s_SHA256SUMS",
+		p.mirrorURL,
 		name,
 		version,
 		name,
 		version,
-		runtime.GOOS,
-		runtime.GOARCH,
 	)
+	logrus.WithFields(logrus.Fields{
+		"url": url,
+	}).Debug("Downloading provider checksums")
+
+	req, err := http.NewRequestWithContext(p.context, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := p.httpclient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unsuccessful request to %s: %s", url, resp.Status)
+	}
+
+	// Each line of the file is made of a checksum and a file name separated by two spaces
+	archive := providerArchiveName(name, version)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == archive {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no checksum found for %s in %s", archive, url)
 }
 
-func (p *ProviderDownloader) Download(url, path string) error {
+func (p *ProviderDownloader) Download(url, path, checksum string) error {
 	logrus.WithFields(logrus.Fields{
 		"url":  url,
 		"path": path,
@@ -68,13 +124,17 @@ func (p *ProviderDownloader) Download(url, path string) error {
 	}
 	defer f.Close()
 	defer os.Remove(f.Name())
-	n, err := getter.Copy(p.context, f, resp.Body)
+	hash := sha256.New()
+	n, err := getter.Copy(p.context, io.MultiWriter(f, hash), resp.Body)
 	if err == nil && n < resp.ContentLength {
 		err = fmt.Errorf("incorrect response size: expected %d bytes, but got %d bytes", resp.ContentLength, n)
 	}
 	if err != nil {
 		return err
 	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != strings.ToLower(checksum) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", url, checksum, actual)
+	}
 	logrus.WithFields(logrus.Fields{
 		"src": f.Name(),
 		"dst": path,
@@ -85,3 +145,7 @@ func (p *ProviderDownloader) Download(url, path string) error {
 	}
 	return nil
 }
+
+func providerArchiveName(name, version string) string {
+	return fmt.Sprintf("terraform-provider-%s_%s_%s_%s.zip", name, version, runtime.GOOS, runtime.GOARCH)
+}
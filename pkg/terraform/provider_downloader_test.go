@@ -1,10 +1,13 @@
 package terraform
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"path"
+	"runtime"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -17,13 +20,14 @@ import (
 func TestProviderDownloader_Download(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
-	downloader := NewProviderDownloader()
+	downloader := NewProviderDownloader("")
 	url := downloader.GetProviderUrl("aws", "3.19.0")
 
 	cases := []struct {
 		name       string
 		httpStatus *int
 		testFile   *string
+		checksum   *string
 		responder  httpmock.Responder
 		assert     func(assert *assert.Assertions, tmpDir string, err error)
 	}{
@@ -54,6 +58,18 @@ func TestProviderDownloader_Download(t *testing.T) {
 				assert.Len(infos, 0)
 			},
 		},
+		{
+			name:     "TestChecksumMismatch",
+			testFile: aws.String("terraform-provider-aws_3.5.0_linux_amd64.zip"),
+			checksum: aws.String("0000000000000000000000000000000000000000000000000000000000000000"),
+			assert: func(assert *assert.Assertions, tmpDir string, err error) {
+				assert.NotNil(err)
+				assert.Contains(err.Error(), fmt.Sprintf("checksum mismatch for %s: expected 0000000000000000000000000000000000000000000000000000000000000000", url))
+				infos, err := ioutil.ReadDir(tmpDir)
+				assert.Nil(err)
+				assert.Len(infos, 0)
+			},
+		},
 		{
 			name:     "TestValidZip",
 			testFile: aws.String("terraform-provider-aws_3.5.0_linux_amd64.zip"),
@@ -78,6 +94,7 @@ func TestProviderDownloader_Download(t *testing.T) {
 				c.httpStatus = aws.Int(http.StatusOK)
 			}
 
+			checksum := ""
 			if c.responder != nil {
 				httpmock.RegisterResponder("GET", url, c.responder)
 			} else {
@@ -86,14 +103,57 @@ func TestProviderDownloader_Download(t *testing.T) {
 					if err != nil {
 						tt.Error(err)
 					}
+					sum := sha256.Sum256(body)
+					checksum = hex.EncodeToString(sum[:])
 					httpmock.RegisterResponder("GET", url, httpmock.NewBytesResponder(*c.httpStatus, body))
 				}
 			}
+			if c.checksum != nil {
+				checksum = *c.checksum
+			}
 
-			err := downloader.Download(url, tmpDir)
+			err := downloader.Download(url, tmpDir, checksum)
 
 			c.assert(assert, tmpDir, err)
 		})
 
 	}
 }
+
+func TestProviderDownloader_GetProviderUrl(t *testing.T) {
+	assert.Equal(
+		t,
+		fmt.Sprintf("https://releases.hashicorp.com/terraform-provider-aws/3.19.0/terraform-provider-aws_3.19.0_%s_%s.zip", runtime.GOOS, runtime.GOARCH),
+		NewProviderDownloader("").GetProviderUrl("aws", "3.19.0"),
+	)
+	assert.Equal(
+		t,
+		fmt.Sprintf("https://mirror.example.com/hashicorp/terraform-provider-aws/3.19.0/terraform-provider-aws_3.19.0_%s_%s.zip", runtime.GOOS, runtime.GOARCH),
+		NewProviderDownloader("https://mirror.example.com/hashicorp/").GetProviderUrl("aws", "3.19.0"),
+	)
+}
+
+func TestProviderDownloader_GetProviderChecksum(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	downloader := NewProviderDownloader("https://mirror.example.com")
+	url := "https://mirror.example.com/terraform-provider-aws/3.19.0/terraform-provider-aws_3.19.0_SHA256SUMS"
+	archive := fmt.Sprintf("terraform-provider-aws_3.19.0_%s_%s.zip", runtime.GOOS, runtime.GOARCH)
+
+	httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(http.StatusOK, fmt.Sprintf(
+		"1111111111111111111111111111111111111111111111111111111111111111  terraform-provider-aws_3.19.0_plan9_386.zip\n"+
+			"2222222222222222222222222222222222222222222222222222222222222222  %s\n",
+		archive,
+	)))
+	checksum, err := downloader.GetProviderChecksum("aws", "3.19.0")
+	assert.Nil(t, err)
+	assert.Equal(t, "2222222222222222222222222222222222222222222222222222222222222222", checksum)
+
+	httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(http.StatusOK, "1111111111111111111111111111111111111111111111111111111111111111  terraform-provider-aws_3.19.0_plan9_386.zip\n"))
+	_, err = downloader.GetProviderChecksum("aws", "3.19.0")
+	assert.EqualError(t, err, fmt.Sprintf("no checksum found for %s in %s", archive, url))
+
+	httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(http.StatusNotFound, ""))
+	_, err = downloader.GetProviderChecksum("aws", "3.19.0")
+	assert.EqualError(t, err, fmt.Sprintf("unsuccessful request to %s: 404", url))
+}
@@ -11,10 +11,12 @@ func NewTerraformProvider(meta discovery.PluginMeta) (*plugin.GRPCProvider, erro
 	// so we can build the actual RPC-implemented provider.
 	rpcClient, err := client.Client()
 	if err != nil {
+		client.Kill()
 		return nil, err
 	}
 	raw, err := rpcClient.Dispense(plugin.ProviderPluginName)
 	if err != nil {
+		client.Kill()
 		return nil, err
 	}
 
@@ -1,69 +1,352 @@
 package terraform
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
 
+	"github.com/hashicorp/go-getter"
 	"github.com/mitchellh/go-homedir"
 	"github.com/sirupsen/logrus"
 )
 
-const awsProviderName = "terraform-provider-aws_v3.19.0_x5"
+// DefaultAwsProviderVersion is the version of the AWS provider resources have been generated from
+const DefaultAwsProviderVersion = "3.19.0"
+
+// checksumSuffix is the suffix of the file recording the SHA256 of an installed provider binary
+// and the zh: hash of the verified archive it has been extracted from
+const checksumSuffix = ".sha256"
 
 type HomeDirInterface interface {
 	Dir() (string, error)
 }
 
+// ProviderInstallerOptions holds where providers are installed from
+type ProviderInstallerOptions struct {
+	ProviderPath string   // Provider binary to use instead of installing one
+	PluginDir    string   // Filesystem mirror of providers, as created by terraform providers mirror
+	MirrorURL    string   // Base URL of a mirror of releases.hashicorp.com to download providers from
+	Version      string   // Version of the AWS provider, defaults to DefaultAwsProviderVersion
+	Hashes       []string // Hashes of the provider locked in the dependency lock file, plugin directories are verified against them
+	// Providers of plugin directories whose hash is not locked are used unverified instead of being refused
+	AllowUnverified bool
+}
+
 type ProviderInstaller struct {
 	downloader ProviderDownloaderInterface
 	homeDir    string
+	options    ProviderInstallerOptions
 }
 
-func NewProviderInstaller() (*ProviderInstaller, error) {
+func NewProviderInstaller(options ProviderInstallerOptions) (*ProviderInstaller, error) {
 	homedir, err := homedir.Dir()
 	if err != nil {
 		homedir = ""
 	}
 	return &ProviderInstaller{
-		NewProviderDownloader(),
+		NewProviderDownloader(options.MirrorURL),
 		homedir,
+		options,
 	}, nil
 }
 
+// AwsVersion returns the version of the AWS provider to install
+func (p *ProviderInstaller) AwsVersion() string {
+	if p.options.Version == "" {
+		return DefaultAwsProviderVersion
+	}
+	return p.options.Version
+}
+
 func (p *ProviderInstaller) GetAws() (string, error) {
+	if p.options.ProviderPath != "" {
+		return p.getLocalProvider(p.options.ProviderPath)
+	}
+
 	if p.homeDir == "" {
 		p.homeDir = os.TempDir()
 	}
 	providerDir := path.Join(p.homeDir, fmt.Sprintf("/.driftctl/plugins/%s_%s/", runtime.GOOS, runtime.GOARCH))
-	providerPath := path.Join(providerDir, awsProviderName)
+	providerName := providerBinaryName(AWS, p.AwsVersion())
+	providerPath := path.Join(providerDir, providerName)
 
 	info, err := os.Stat(providerPath)
-	if err != nil && os.IsNotExist(err) {
-		logrus.WithFields(logrus.Fields{
-			"path": providerPath,
-		}).Debug("AWS provider not found, downloading ...")
-		fmt.Printf("Downloading AWS terraform provider: %s\n", awsProviderName)
-		err := p.downloader.Download(
-			p.downloader.GetProviderUrl(AWS, "3.19.0"),
-			providerDir,
-		)
-		if err != nil {
-			return "", err
-		}
-		logrus.Debug("Download successful")
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
 	}
-
 	if info != nil && info.IsDir() {
 		return "", fmt.Errorf("found directory instead of provider binary in %s", providerPath)
 	}
 
+	// Installed binaries are verified on every run, those installed from an archive that was not verified
+	// or that is not locked in the dependency lock file are installed again
 	if info != nil {
+		archiveHash, err := verifyRecordedChecksum(providerPath)
+		if err != nil {
+			return "", err
+		}
+		if archiveHash != "" && !p.lockedOtherwise(archiveHash) {
+			logrus.WithFields(logrus.Fields{
+				"path": providerPath,
+			}).Debug("Found existing aws provider")
+			return providerPath, nil
+		}
 		logrus.WithFields(logrus.Fields{
 			"path": providerPath,
-		}).Debug("Found existing aws provider")
+		}).Debug("Existing aws provider cannot be verified, installing it again")
+	}
+
+	if p.options.PluginDir != "" {
+		return p.installFromPluginDir(providerDir)
+	}
+	logrus.WithFields(logrus.Fields{
+		"path": providerPath,
+	}).Debug("AWS provider not found, downloading ...")
+	fmt.Fprintf(os.Stderr, "Downloading AWS terraform provider: %s\n", providerName)
+	checksum, err := p.downloader.GetProviderChecksum(AWS, p.AwsVersion())
+	if err != nil {
+		return "", err
+	}
+	// The archive is verified against SHA256SUMS by the download, and against the lock file when it locks zh: hashes
+	if _, err := p.verifyLockedHash(p.downloader.GetProviderUrl(AWS, p.AwsVersion()), "zh:"+checksum); err != nil {
+		return "", err
+	}
+	err = p.downloader.Download(
+		p.downloader.GetProviderUrl(AWS, p.AwsVersion()),
+		providerDir,
+		checksum,
+	)
+	if err != nil {
+		return "", err
+	}
+	logrus.Debug("Download successful")
+
+	if err := recordChecksum(providerPath, "zh:"+checksum); err != nil {
+		return "", err
+	}
+	return providerPath, nil
+}
+
+func (p *ProviderInstaller) getLocalProvider(providerPath string) (string, error) {
+	info, err := os.Stat(providerPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to find provider binary %s: %w", providerPath, err)
 	}
+	if info.IsDir() {
+		return "", fmt.Errorf("found directory instead of provider binary in %s", providerPath)
+	}
+	logrus.WithFields(logrus.Fields{
+		"path": providerPath,
+	}).Debug("Using local aws provider")
+	return providerPath, nil
+}
 
+// installFromPluginDir looks the provider up in a filesystem mirror, using either the unpacked
+// or the packed layout of Terraform (HOSTNAME/NAMESPACE/TYPE/VERSION/TARGET/ or HOSTNAME/NAMESPACE/TYPE/*.zip)
+func (p *ProviderInstaller) installFromPluginDir(providerDir string) (string, error) {
+	version := p.AwsVersion()
+	mirrorDir := path.Join(p.options.PluginDir, "registry.terraform.io", "hashicorp", AWS)
+	target := fmt.Sprintf("%s_%s", runtime.GOOS, runtime.GOARCH)
+
+	unpackedPath := path.Join(mirrorDir, version, target, providerBinaryName(AWS, version))
+	if _, err := os.Stat(unpackedPath); err == nil {
+		hash, err := packageHash(path.Dir(unpackedPath))
+		if err != nil {
+			return "", err
+		}
+		if err := p.verifyPluginDirHash(path.Dir(unpackedPath), hash); err != nil {
+			return "", err
+		}
+		return p.getLocalProvider(unpackedPath)
+	}
+
+	packedPath := path.Join(mirrorDir, providerArchiveName(AWS, version))
+	if _, err := os.Stat(packedPath); err != nil {
+		return "", fmt.Errorf("unable to find AWS provider %s in plugin directory %s", version, p.options.PluginDir)
+	}
+	checksum, err := fileChecksum(packedPath)
+	if err != nil {
+		return "", err
+	}
+	verified, err := p.verifyLockedHash(packedPath, "zh:"+checksum)
+	if err != nil {
+		return "", err
+	}
+	if !verified {
+		if err := p.allowUnverified(packedPath); err != nil {
+			return "", err
+		}
+	}
+	logrus.WithFields(logrus.Fields{
+		"src": packedPath,
+		"dst": providerDir,
+	}).Debug("Decompressing provider archive from plugin directory")
+	unzip := getter.ZipDecompressor{}
+	if err := unzip.Decompress(providerDir, packedPath, true, 0); err != nil {
+		return "", err
+	}
+	providerPath := path.Join(providerDir, providerBinaryName(AWS, version))
+	// Binaries extracted from an unverified archive are not recorded, they are extracted and refused again on next runs
+	if !verified {
+		return providerPath, nil
+	}
+	if err := recordChecksum(providerPath, "zh:"+checksum); err != nil {
+		return "", err
+	}
 	return providerPath, nil
 }
+
+// verifyPluginDirHash verifies a provider found in a plugin directory against the lock file,
+// there is no other source of trust for it
+func (p *ProviderInstaller) verifyPluginDirHash(src, hash string) error {
+	verified, err := p.verifyLockedHash(src, hash)
+	if err != nil || verified {
+		return err
+	}
+	return p.allowUnverified(src)
+}
+
+// allowUnverified refuses a provider that cannot be verified, unless the user explicitly allowed it
+func (p *ProviderInstaller) allowUnverified(src string) error {
+	if !p.options.AllowUnverified {
+		return fmt.Errorf(
+			"unable to verify %s: no hash of the AWS provider is locked in the dependency lock file, "+
+				"lock it with terraform providers lock or use --allow-unverified-provider",
+			src,
+		)
+	}
+	logrus.WithFields(logrus.Fields{
+		"src": src,
+	}).Warn("No hash of the AWS provider is locked in the dependency lock file, the provider is not verified")
+	return nil
+}
+
+// verifyLockedHash checks a hash of the provider against the locked hashes of the same scheme (h1: for unpacked
+// directories, zh: for archives), it returns false when no hash of this scheme is locked
+func (p *ProviderInstaller) verifyLockedHash(src, hash string) (bool, error) {
+	for _, lockedHash := range p.options.Hashes {
+		if lockedHash == hash {
+			return true, nil
+		}
+	}
+	if p.lockedOtherwise(hash) {
+		return false, fmt.Errorf("checksum mismatch for %s: %s is not locked in the dependency lock file", src, hash)
+	}
+	return false, nil
+}
+
+// lockedOtherwise returns true when hashes of the same scheme are locked, but not this one
+func (p *ProviderInstaller) lockedOtherwise(hash string) bool {
+	scheme := hash[:strings.Index(hash, ":")+1]
+	locked := false
+	for _, lockedHash := range p.options.Hashes {
+		if lockedHash == hash {
+			return false
+		}
+		if strings.HasPrefix(lockedHash, scheme) {
+			locked = true
+		}
+	}
+	return locked
+}
+
+// recordChecksum writes the SHA256 of a binary next to it, along with the hash of the verified archive
+// it has been extracted from, the binary is verified on every run
+func recordChecksum(binaryPath, archiveHash string) error {
+	checksum, err := fileChecksum(binaryPath)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(binaryPath+checksumSuffix, []byte(fmt.Sprintf("%s %s\n", checksum, archiveHash)), 0600)
+}
+
+// verifyRecordedChecksum returns the hash of the archive the binary has been extracted from,
+// or an empty string when nothing has been recorded for the binary
+func verifyRecordedChecksum(binaryPath string) (string, error) {
+	recorded, err := ioutil.ReadFile(binaryPath + checksumSuffix)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	// Records of previous versions of driftctl do not hold the hash of the archive
+	fields := strings.Fields(string(recorded))
+	if len(fields) != 2 {
+		return "", nil
+	}
+	checksum, err := fileChecksum(binaryPath)
+	if err != nil {
+		return "", err
+	}
+	if expected := fields[0]; checksum != expected {
+		return "", fmt.Errorf(
+			"checksum mismatch for provider %s: expected %s, got %s, remove it to install it again",
+			binaryPath,
+			expected,
+			checksum,
+		)
+	}
+	return fields[1], nil
+}
+
+func fileChecksum(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// packageHash returns the h1: hash Terraform locks for an unpacked provider directory, the SHA256 of the sorted list
+// of the SHA256 and relative path of its files
+func packageHash(dir string) (string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			rel, err := filepath.Rel(dir, file)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	summary := sha256.New()
+	for _, file := range files {
+		checksum, err := fileChecksum(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(summary, "%s  %s\n", checksum, file)
+	}
+	return "h1:" + base64.StdEncoding.EncodeToString(summary.Sum(nil)), nil
+}
+
+// providerBinaryName returns the name of the binary of a provider speaking the protocol 5 of terraform plugins
+func providerBinaryName(name, version string) string {
+	return fmt.Sprintf("terraform-provider-%s_v%s_x5", name, version)
+}
@@ -1,17 +1,39 @@
 package terraform
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"runtime"
 	"testing"
 
 	"github.com/cloudskiff/driftctl/mocks"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// emptyChecksum is the SHA256 of the empty fake providers created by tests
+const emptyChecksum = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// emptyRecord is recorded for empty fake providers downloaded from an archive whose checksum is "checksum"
+const emptyRecord = emptyChecksum + " zh:checksum\n"
+
+// downloadFakeProvider creates the binary Download would extract in its destination directory
+func downloadFakeProvider(binaryName string) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		dir := args.String(1)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			panic(err)
+		}
+		if _, err := os.Create(path.Join(dir, binaryName)); err != nil {
+			panic(err)
+		}
+	}
+}
+
 func TestProviderInstallerGetAwsDoesNotExist(t *testing.T) {
 
 	assert := assert.New(t)
@@ -21,7 +43,10 @@ func TestProviderInstallerGetAwsDoesNotExist(t *testing.T) {
 	fakeUrl := "https://example.com"
 	mockDownloader := mocks.ProviderDownloaderInterface{}
 	mockDownloader.On("GetProviderUrl", "aws", "3.19.0").Return(fakeUrl)
-	mockDownloader.On("Download", fakeUrl, path.Join(fakeTmpHome, expectedSubFolder)).Return(nil)
+	mockDownloader.On("GetProviderChecksum", "aws", "3.19.0").Return("checksum", nil)
+	mockDownloader.On("Download", fakeUrl, path.Join(fakeTmpHome, expectedSubFolder), "checksum").
+		Run(downloadFakeProvider(providerBinaryName("aws", "3.19.0"))).
+		Return(nil)
 
 	installer := ProviderInstaller{
 		downloader: &mockDownloader,
@@ -32,7 +57,10 @@ func TestProviderInstallerGetAwsDoesNotExist(t *testing.T) {
 	mockDownloader.AssertExpectations(t)
 
 	assert.Nil(err)
-	assert.Equal(path.Join(fakeTmpHome, expectedSubFolder, awsProviderName), providerPath)
+	assert.Equal(path.Join(fakeTmpHome, expectedSubFolder, providerBinaryName("aws", "3.19.0")), providerPath)
+	recorded, err := ioutil.ReadFile(providerPath + ".sha256")
+	assert.Nil(err)
+	assert.Equal(emptyRecord, string(recorded))
 
 }
 
@@ -45,7 +73,10 @@ func TestProviderInstallerGetAwsWithoutHomeDir(t *testing.T) {
 	fakeUrl := "https://example.com"
 	mockDownloader := mocks.ProviderDownloaderInterface{}
 	mockDownloader.On("GetProviderUrl", "aws", "3.19.0").Return(fakeUrl)
-	mockDownloader.On("Download", fakeUrl, path.Join(expectedHomeDir, expectedSubFolder)).Return(nil)
+	mockDownloader.On("GetProviderChecksum", "aws", "3.19.0").Return("checksum", nil)
+	mockDownloader.On("Download", fakeUrl, path.Join(expectedHomeDir, expectedSubFolder), "checksum").
+		Run(downloadFakeProvider(providerBinaryName("aws", "3.19.0"))).
+		Return(nil)
 
 	installer := ProviderInstaller{
 		downloader: &mockDownloader,
@@ -53,9 +84,11 @@ func TestProviderInstallerGetAwsWithoutHomeDir(t *testing.T) {
 
 	providerPath, err := installer.GetAws()
 	mockDownloader.AssertExpectations(t)
+	defer os.Remove(providerPath)
+	defer os.Remove(providerPath + ".sha256")
 
 	assert.Nil(err)
-	assert.Equal(path.Join(expectedHomeDir, expectedSubFolder, awsProviderName), providerPath)
+	assert.Equal(path.Join(expectedHomeDir, expectedSubFolder, providerBinaryName("aws", "3.19.0")), providerPath)
 
 }
 
@@ -68,7 +101,11 @@ func TestProviderInstallerGetAwsAlreadyExist(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	_, err = os.Create(path.Join(fakeTmpHome, expectedSubFolder, awsProviderName))
+	_, err = os.Create(path.Join(fakeTmpHome, expectedSubFolder, providerBinaryName("aws", "3.19.0")))
+	if err != nil {
+		t.Error(err)
+	}
+	err = ioutil.WriteFile(path.Join(fakeTmpHome, expectedSubFolder, providerBinaryName("aws", "3.19.0")+".sha256"), []byte(emptyRecord), 0600)
 	if err != nil {
 		t.Error(err)
 	}
@@ -84,8 +121,131 @@ func TestProviderInstallerGetAwsAlreadyExist(t *testing.T) {
 	mockDownloader.AssertExpectations(t)
 
 	assert.Nil(err)
-	assert.Equal(path.Join(fakeTmpHome, expectedSubFolder, awsProviderName), providerPath)
+	assert.Equal(path.Join(fakeTmpHome, expectedSubFolder, providerBinaryName("aws", "3.19.0")), providerPath)
+
+}
+
+func TestProviderInstallerGetAwsAlreadyExistWithChecksumMismatch(t *testing.T) {
+
+	assert := assert.New(t)
+	fakeTmpHome := t.TempDir()
+	expectedSubFolder := fmt.Sprintf("/.driftctl/plugins/%s_%s", runtime.GOOS, runtime.GOARCH)
+	binaryPath := path.Join(fakeTmpHome, expectedSubFolder, providerBinaryName("aws", "3.19.0"))
+	err := os.MkdirAll(path.Join(fakeTmpHome, expectedSubFolder), 0755)
+	if err != nil {
+		t.Error(err)
+	}
+	err = ioutil.WriteFile(binaryPath, []byte("tampered"), 0755)
+	if err != nil {
+		t.Error(err)
+	}
+	err = ioutil.WriteFile(binaryPath+".sha256", []byte(emptyRecord), 0600)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mockDownloader := mocks.ProviderDownloaderInterface{}
+
+	installer := ProviderInstaller{
+		downloader: &mockDownloader,
+		homeDir:    fakeTmpHome,
+	}
+
+	providerPath, err := installer.GetAws()
+	mockDownloader.AssertExpectations(t)
+
+	assert.Empty(providerPath)
+	assert.EqualError(
+		err,
+		fmt.Sprintf(
+			"checksum mismatch for provider %s: expected %s, got %s, remove it to install it again",
+			binaryPath,
+			emptyChecksum,
+			"d121be3103007b41edf96f8262925f8c7d61894afe9a041843b631f69445bc57",
+		),
+	)
+
+}
+
+func TestProviderInstallerGetAwsAlreadyExistWithoutVerifiedChecksum(t *testing.T) {
+
+	expectedSubFolder := fmt.Sprintf("/.driftctl/plugins/%s_%s", runtime.GOOS, runtime.GOARCH)
+	fakeUrl := "https://example.com"
+
+	// Binaries that cannot be verified are downloaded again
+	cases := []struct {
+		name     string
+		record   string
+		hashes   []string
+		expected string
+	}{
+		{
+			name: "no checksum recorded",
+		},
+		{
+			name:   "checksum recorded by previous versions",
+			record: emptyChecksum,
+		},
+		{
+			name:   "archive no longer locked",
+			record: emptyChecksum + " zh:previous\n",
+			hashes: []string{"zh:checksum"},
+		},
+		{
+			name:     "archive not locked",
+			record:   emptyRecord,
+			hashes:   []string{"zh:locked"},
+			expected: fmt.Sprintf("checksum mismatch for %s: zh:checksum is not locked in the dependency lock file", fakeUrl),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			assert := assert.New(tt)
+			fakeTmpHome := tt.TempDir()
+			binaryPath := path.Join(fakeTmpHome, expectedSubFolder, providerBinaryName("aws", "3.19.0"))
+			if err := os.MkdirAll(path.Dir(binaryPath), 0755); err != nil {
+				tt.Fatal(err)
+			}
+			if _, err := os.Create(binaryPath); err != nil {
+				tt.Fatal(err)
+			}
+			if c.record != "" {
+				if err := ioutil.WriteFile(binaryPath+".sha256", []byte(c.record), 0600); err != nil {
+					tt.Fatal(err)
+				}
+			}
+
+			mockDownloader := mocks.ProviderDownloaderInterface{}
+			mockDownloader.On("GetProviderUrl", "aws", "3.19.0").Return(fakeUrl)
+			mockDownloader.On("GetProviderChecksum", "aws", "3.19.0").Return("checksum", nil)
+			if c.expected == "" {
+				mockDownloader.On("Download", fakeUrl, path.Join(fakeTmpHome, expectedSubFolder), "checksum").
+					Run(downloadFakeProvider(providerBinaryName("aws", "3.19.0"))).
+					Return(nil)
+			}
+
+			installer := ProviderInstaller{
+				downloader: &mockDownloader,
+				homeDir:    fakeTmpHome,
+				options:    ProviderInstallerOptions{Hashes: c.hashes},
+			}
 
+			providerPath, err := installer.GetAws()
+			mockDownloader.AssertExpectations(tt)
+
+			if c.expected != "" {
+				assert.Empty(providerPath)
+				assert.EqualError(err, c.expected)
+				return
+			}
+			assert.Nil(err)
+			assert.Equal(binaryPath, providerPath)
+			recorded, err := ioutil.ReadFile(providerPath + ".sha256")
+			assert.Nil(err)
+			assert.Equal(emptyRecord, string(recorded))
+		})
+	}
 }
 
 func TestProviderInstallerGetAwsAlreadyExistButIsDirectory(t *testing.T) {
@@ -93,7 +253,7 @@ func TestProviderInstallerGetAwsAlreadyExistButIsDirectory(t *testing.T) {
 	assert := assert.New(t)
 	fakeTmpHome := t.TempDir()
 	expectedSubFolder := fmt.Sprintf("/.driftctl/plugins/%s_%s", runtime.GOOS, runtime.GOARCH)
-	invalidDirPath := path.Join(fakeTmpHome, expectedSubFolder, awsProviderName)
+	invalidDirPath := path.Join(fakeTmpHome, expectedSubFolder, providerBinaryName("aws", "3.19.0"))
 	err := os.MkdirAll(invalidDirPath, 0755)
 	if err != nil {
 		t.Error(err)
@@ -120,3 +280,256 @@ func TestProviderInstallerGetAwsAlreadyExistButIsDirectory(t *testing.T) {
 	)
 
 }
+
+func TestProviderInstallerGetAwsChecksumError(t *testing.T) {
+
+	assert := assert.New(t)
+	fakeTmpHome := t.TempDir()
+
+	mockDownloader := mocks.ProviderDownloaderInterface{}
+	mockDownloader.On("GetProviderChecksum", "aws", "3.19.0").Return("", errors.New("unsuccessful request"))
+
+	installer := ProviderInstaller{
+		downloader: &mockDownloader,
+		homeDir:    fakeTmpHome,
+	}
+
+	providerPath, err := installer.GetAws()
+	mockDownloader.AssertExpectations(t)
+
+	assert.Empty(providerPath)
+	assert.EqualError(err, "unsuccessful request")
+
+}
+
+func TestProviderInstallerGetAwsFromProviderPath(t *testing.T) {
+
+	assert := assert.New(t)
+	providerPath := path.Join(t.TempDir(), "terraform-provider-aws")
+	_, err := os.Create(providerPath)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mockDownloader := mocks.ProviderDownloaderInterface{}
+
+	installer := ProviderInstaller{
+		downloader: &mockDownloader,
+		homeDir:    t.TempDir(),
+		options:    ProviderInstallerOptions{ProviderPath: providerPath},
+	}
+
+	got, err := installer.GetAws()
+	mockDownloader.AssertExpectations(t)
+
+	assert.Nil(err)
+	assert.Equal(providerPath, got)
+
+	installer.options.ProviderPath = path.Join(t.TempDir(), "missing")
+	_, err = installer.GetAws()
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "unable to find provider binary")
+
+}
+
+func TestProviderInstallerGetAwsFromPluginDir(t *testing.T) {
+
+	target := fmt.Sprintf("%s_%s", runtime.GOOS, runtime.GOARCH)
+	archive, err := ioutil.ReadFile("./testdata/terraform-provider-aws_3.5.0_linux_amd64.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	setupUnpacked := func(pluginDir string) error {
+		dir := path.Join(pluginDir, "registry.terraform.io/hashicorp/aws/3.19.0", target)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		_, err := os.Create(path.Join(dir, providerBinaryName("aws", "3.19.0")))
+		return err
+	}
+	// The test archive contains a fake 3.5.0 provider
+	setupPacked := func(pluginDir string) error {
+		dir := path.Join(pluginDir, "registry.terraform.io/hashicorp/aws")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(path.Join(dir, fmt.Sprintf("terraform-provider-aws_3.5.0_%s.zip", target)), archive, 0644)
+	}
+	// Hashes of the unpacked empty fake provider and of the test archive
+	unpackedHash := "h1:5XVZHV+ISYAU5OPxlaCbYPvrQpmMMcCtQbhcZ2UZeIc="
+	packedHash := "zh:2db5345840993edb9bd17ba5715f6bcdca87613dc150b9590f2da14d34aa5b52"
+
+	cases := []struct {
+		name            string
+		version         string
+		hashes          []string
+		allowUnverified bool
+		setup           func(pluginDir string) error
+		assert          func(assert *assert.Assertions, homeDir, pluginDir, providerPath string, err error)
+	}{
+		{
+			name:  "unpacked layout without locked hash",
+			setup: setupUnpacked,
+			assert: func(assert *assert.Assertions, homeDir, pluginDir, providerPath string, err error) {
+				assert.Empty(providerPath)
+				assert.EqualError(err, fmt.Sprintf(
+					"unable to verify %s: no hash of the AWS provider is locked in the dependency lock file, "+
+						"lock it with terraform providers lock or use --allow-unverified-provider",
+					path.Join(pluginDir, "registry.terraform.io/hashicorp/aws/3.19.0", target),
+				))
+			},
+		},
+		{
+			name:            "unpacked layout allowed unverified",
+			allowUnverified: true,
+			setup:           setupUnpacked,
+			assert: func(assert *assert.Assertions, homeDir, pluginDir, providerPath string, err error) {
+				assert.Nil(err)
+				assert.Equal(path.Join(pluginDir, "registry.terraform.io/hashicorp/aws/3.19.0", target, providerBinaryName("aws", "3.19.0")), providerPath)
+			},
+		},
+		{
+			name:   "unpacked layout with locked hash",
+			hashes: []string{unpackedHash, packedHash},
+			setup:  setupUnpacked,
+			assert: func(assert *assert.Assertions, homeDir, pluginDir, providerPath string, err error) {
+				assert.Nil(err)
+				assert.Equal(path.Join(pluginDir, "registry.terraform.io/hashicorp/aws/3.19.0", target, providerBinaryName("aws", "3.19.0")), providerPath)
+			},
+		},
+		{
+			name:   "unpacked layout with another locked hash",
+			hashes: []string{"h1:z7gLKpNN4FAYvBqQ5pnGHi1eWX0hmn/nFn4EVYROSz8="},
+			setup:  setupUnpacked,
+			assert: func(assert *assert.Assertions, homeDir, pluginDir, providerPath string, err error) {
+				assert.Empty(providerPath)
+				assert.EqualError(err, fmt.Sprintf(
+					"checksum mismatch for %s: %s is not locked in the dependency lock file",
+					path.Join(pluginDir, "registry.terraform.io/hashicorp/aws/3.19.0", target),
+					unpackedHash,
+				))
+			},
+		},
+		{
+			name:    "packed layout without locked hash",
+			version: "3.5.0",
+			setup:   setupPacked,
+			assert: func(assert *assert.Assertions, homeDir, pluginDir, providerPath string, err error) {
+				assert.Empty(providerPath)
+				assert.EqualError(err, fmt.Sprintf(
+					"unable to verify %s: no hash of the AWS provider is locked in the dependency lock file, "+
+						"lock it with terraform providers lock or use --allow-unverified-provider",
+					path.Join(pluginDir, "registry.terraform.io/hashicorp/aws", fmt.Sprintf("terraform-provider-aws_3.5.0_%s.zip", target)),
+				))
+			},
+		},
+		{
+			name:            "packed layout allowed unverified",
+			version:         "3.5.0",
+			allowUnverified: true,
+			setup:           setupPacked,
+			assert: func(assert *assert.Assertions, homeDir, pluginDir, providerPath string, err error) {
+				assert.Nil(err)
+				providerDir := path.Join(homeDir, "/.driftctl/plugins", target)
+				assert.Equal(path.Join(providerDir, "terraform-provider-aws_v3.5.0_x5"), providerPath)
+				// Unverified binaries are not trusted on next runs
+				_, err = os.Stat(providerPath + ".sha256")
+				assert.True(os.IsNotExist(err))
+			},
+		},
+		{
+			name:    "packed layout with locked hash",
+			version: "3.5.0",
+			hashes:  []string{packedHash},
+			setup:   setupPacked,
+			assert: func(assert *assert.Assertions, homeDir, pluginDir, providerPath string, err error) {
+				assert.Nil(err)
+				assert.Equal(path.Join(homeDir, "/.driftctl/plugins", target, "terraform-provider-aws_v3.5.0_x5"), providerPath)
+				// Extracted binaries are verified on next runs
+				recorded, err := ioutil.ReadFile(providerPath + ".sha256")
+				assert.Nil(err)
+				assert.Contains(string(recorded), packedHash)
+			},
+		},
+		{
+			name:    "packed layout with another locked hash",
+			version: "3.5.0",
+			hashes:  []string{"zh:01f562a6a31fe46a8ca74804f360e3452b26f71abc549ce1f0ab5a8af2484cdf"},
+			setup:   setupPacked,
+			assert: func(assert *assert.Assertions, homeDir, pluginDir, providerPath string, err error) {
+				assert.Empty(providerPath)
+				assert.EqualError(err, fmt.Sprintf(
+					"checksum mismatch for %s: %s is not locked in the dependency lock file",
+					path.Join(pluginDir, "registry.terraform.io/hashicorp/aws", fmt.Sprintf("terraform-provider-aws_3.5.0_%s.zip", target)),
+					packedHash,
+				))
+			},
+		},
+		{
+			name: "provider not found",
+			setup: func(pluginDir string) error {
+				return nil
+			},
+			assert: func(assert *assert.Assertions, homeDir, pluginDir, providerPath string, err error) {
+				assert.Empty(providerPath)
+				assert.EqualError(err, fmt.Sprintf("unable to find AWS provider 3.19.0 in plugin directory %s", pluginDir))
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			homeDir, pluginDir := tt.TempDir(), tt.TempDir()
+			if err := c.setup(pluginDir); err != nil {
+				tt.Fatal(err)
+			}
+
+			mockDownloader := mocks.ProviderDownloaderInterface{}
+			installer := ProviderInstaller{
+				downloader: &mockDownloader,
+				homeDir:    homeDir,
+				options: ProviderInstallerOptions{
+					PluginDir:       pluginDir,
+					Version:         c.version,
+					Hashes:          c.hashes,
+					AllowUnverified: c.allowUnverified,
+				},
+			}
+
+			providerPath, err := installer.GetAws()
+			mockDownloader.AssertExpectations(tt)
+
+			c.assert(assert.New(tt), homeDir, pluginDir, providerPath, err)
+		})
+	}
+}
+
+func TestProviderInstallerGetAwsWithVersion(t *testing.T) {
+
+	assert := assert.New(t)
+	fakeTmpHome := t.TempDir()
+
+	expectedSubFolder := fmt.Sprintf("/.driftctl/plugins/%s_%s", runtime.GOOS, runtime.GOARCH)
+	fakeUrl := "https://example.com"
+	mockDownloader := mocks.ProviderDownloaderInterface{}
+	mockDownloader.On("GetProviderUrl", "aws", "3.30.0").Return(fakeUrl)
+	mockDownloader.On("GetProviderChecksum", "aws", "3.30.0").Return("checksum", nil)
+	mockDownloader.On("Download", fakeUrl, path.Join(fakeTmpHome, expectedSubFolder), "checksum").
+		Run(downloadFakeProvider("terraform-provider-aws_v3.30.0_x5")).
+		Return(nil)
+
+	installer := ProviderInstaller{
+		downloader: &mockDownloader,
+		homeDir:    fakeTmpHome,
+		options:    ProviderInstallerOptions{Version: "3.30.0"},
+	}
+
+	providerPath, err := installer.GetAws()
+	mockDownloader.AssertExpectations(t)
+
+	assert.Nil(err)
+	assert.Equal("3.30.0", installer.AwsVersion())
+	assert.Equal(path.Join(fakeTmpHome, expectedSubFolder, "terraform-provider-aws_v3.30.0_x5"), providerPath)
+
+}
@@ -0,0 +1,178 @@
+package terraform
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// ReadCacheOptions configures the on-disk cache of resources read through providers
+type ReadCacheOptions struct {
+	Dir     string        // Directory of cached reads, defaults to ~/.driftctl/cache
+	TTL     time.Duration // How long cached reads are used, the cache is disabled when zero
+	Refresh bool          // Read every resource again, cached reads are replaced
+}
+
+// Enabled returns true when reads have to be cached
+func (o ReadCacheOptions) Enabled() bool {
+	return o.TTL > 0
+}
+
+// ReadCacheScope holds what a read depends on besides its arguments
+type ReadCacheScope struct {
+	ProviderVersion string
+	Account         string
+	Region          string // Default region of reads, reads of other regions give it in the aws_region attribute
+}
+
+// CachedResourceReader reads resources through another reader, results are cached on disk
+// and reused until they expire. Errors are never cached.
+type CachedResourceReader struct {
+	reader  ResourceReader
+	options ReadCacheOptions
+	scope   ReadCacheScope
+}
+
+type cachedRead struct {
+	ReadAt time.Time       `json:"read_at"`
+	Type   json.RawMessage `json:"type"`
+	Value  json.RawMessage `json:"value"`
+}
+
+func NewCachedResourceReader(reader ResourceReader, options ReadCacheOptions, scope ReadCacheScope) *CachedResourceReader {
+	if options.Dir == "" {
+		options.Dir = DefaultReadCacheDir()
+	}
+	return &CachedResourceReader{reader, options, scope}
+}
+
+// DefaultReadCacheDir returns the directory reads are cached in by default
+func DefaultReadCacheDir() string {
+	home, err := homedir.Dir()
+	if err != nil {
+		home = os.TempDir()
+	}
+	return path.Join(home, ".driftctl", "cache")
+}
+
+func (r *CachedResourceReader) ReadResource(args ReadResourceArgs) (*cty.Value, error) {
+	file := path.Join(r.options.Dir, r.key(args)+".json")
+	logger := logrus.WithFields(logrus.Fields{
+		"type": args.Ty,
+		"id":   args.ID,
+		"file": file,
+	})
+
+	if !r.options.Refresh {
+		val, err := r.get(file)
+		if err != nil {
+			logger.Debugf("Unable to use cached read: %s", err)
+		}
+		if val != nil {
+			logger.Debug("Using cached read")
+			return val, nil
+		}
+	}
+
+	val, err := r.reader.ReadResource(args)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.set(file, *val); err != nil {
+		logger.Warnf("Unable to cache read: %s", err)
+	}
+	return val, nil
+}
+
+// key identifies a read, a hash is used as ID and attributes may contain any character
+func (r *CachedResourceReader) key(args ReadResourceArgs) string {
+	region := args.Attributes["aws_region"]
+	if region == "" {
+		region = r.scope.Region
+	}
+	key, _ := json.Marshal(struct {
+		ProviderVersion string
+		Account         string
+		Region          string
+		Type            string
+		ID              string
+		Attributes      map[string]string // Encoded with sorted keys
+	}{r.scope.ProviderVersion, r.scope.Account, region, string(args.Ty), args.ID, args.Attributes})
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns a cached read, nil if the resource has not been read or the read has expired
+func (r *CachedResourceReader) get(file string) (*cty.Value, error) {
+	content, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var read cachedRead
+	if err := json.Unmarshal(content, &read); err != nil {
+		return nil, err
+	}
+	if time.Since(read.ReadAt) > r.options.TTL {
+		return nil, nil
+	}
+
+	ty, err := ctyjson.UnmarshalType(read.Type)
+	if err != nil {
+		return nil, err
+	}
+	val, err := ctyjson.Unmarshal(read.Value, ty)
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+func (r *CachedResourceReader) set(file string, val cty.Value) error {
+	ty, err := ctyjson.MarshalType(val.Type())
+	if err != nil {
+		return err
+	}
+	value, err := ctyjson.Marshal(val, val.Type())
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(cachedRead{
+		ReadAt: time.Now(),
+		Type:   ty,
+		Value:  value,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(r.options.Dir, 0700); err != nil {
+		return err
+	}
+	// Reads of the same resource may run at once, each one is written to its own file before being moved
+	tmp, err := ioutil.TempFile(r.options.Dir, "read-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
@@ -0,0 +1,124 @@
+package terraform
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type countingReader struct {
+	reads int
+	val   cty.Value
+	err   error
+}
+
+func (r *countingReader) ReadResource(args ReadResourceArgs) (*cty.Value, error) {
+	r.reads++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &r.val, nil
+}
+
+func tempCacheDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "driftctl-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestCachedResourceReader_ReadResource(t *testing.T) {
+	assert := assert.New(t)
+
+	val := cty.ObjectVal(map[string]cty.Value{
+		"id":   cty.StringVal("bucket"),
+		"tags": cty.MapVal(map[string]cty.Value{"env": cty.StringVal("prod")}),
+	})
+	reader := &countingReader{val: val}
+	options := ReadCacheOptions{Dir: tempCacheDir(t), TTL: time.Hour}
+	scope := ReadCacheScope{ProviderVersion: "3.19.0", Region: "eu-west-3"}
+	args := ReadResourceArgs{Ty: "aws_s3_bucket", ID: "bucket"}
+
+	got, err := NewCachedResourceReader(reader, options, scope).ReadResource(args)
+	assert.Nil(err)
+	assert.True(val.RawEquals(*got))
+
+	// A new reader uses the reads cached by the previous one
+	got, err = NewCachedResourceReader(reader, options, scope).ReadResource(args)
+	assert.Nil(err)
+	assert.True(val.RawEquals(*got))
+	assert.Equal(1, reader.reads)
+
+	// Reads of another region, provider version or ID are not shared
+	_, _ = NewCachedResourceReader(reader, options, ReadCacheScope{ProviderVersion: "3.19.0", Region: "us-east-1"}).ReadResource(args)
+	_, _ = NewCachedResourceReader(reader, options, ReadCacheScope{ProviderVersion: "3.30.0", Region: "eu-west-3"}).ReadResource(args)
+	_, _ = NewCachedResourceReader(reader, options, scope).ReadResource(ReadResourceArgs{Ty: "aws_s3_bucket", ID: "other"})
+	_, _ = NewCachedResourceReader(reader, options, scope).ReadResource(ReadResourceArgs{
+		Ty:         "aws_s3_bucket",
+		ID:         "bucket",
+		Attributes: map[string]string{"aws_region": "us-east-1"},
+	})
+	assert.Equal(5, reader.reads)
+
+	// Refreshing the cache reads resources again
+	refresh := options
+	refresh.Refresh = true
+	_, err = NewCachedResourceReader(reader, refresh, scope).ReadResource(args)
+	assert.Nil(err)
+	assert.Equal(6, reader.reads)
+}
+
+func TestCachedResourceReader_Expired(t *testing.T) {
+	reader := &countingReader{val: cty.NullVal(cty.Object(map[string]cty.Type{"id": cty.String}))}
+	options := ReadCacheOptions{Dir: tempCacheDir(t), TTL: time.Millisecond}
+	args := ReadResourceArgs{Ty: "aws_s3_bucket", ID: "bucket"}
+
+	cached := NewCachedResourceReader(reader, options, ReadCacheScope{})
+	_, _ = cached.ReadResource(args)
+	time.Sleep(10 * time.Millisecond)
+	got, err := cached.ReadResource(args)
+
+	assert.Nil(t, err)
+	assert.True(t, got.IsNull())
+	assert.Equal(t, 2, reader.reads)
+}
+
+func TestCachedResourceReader_Errors(t *testing.T) {
+	reader := &countingReader{err: errors.New("AccessDenied")}
+	options := ReadCacheOptions{Dir: tempCacheDir(t), TTL: time.Hour}
+	args := ReadResourceArgs{Ty: "aws_s3_bucket", ID: "bucket"}
+
+	cached := NewCachedResourceReader(reader, options, ReadCacheScope{})
+	_, err := cached.ReadResource(args)
+	assert.EqualError(t, err, "AccessDenied")
+	_, err = cached.ReadResource(args)
+	assert.EqualError(t, err, "AccessDenied")
+	assert.Equal(t, 2, reader.reads)
+
+	files, _ := ioutil.ReadDir(options.Dir)
+	assert.Len(t, files, 0)
+}
+
+func TestCachedResourceReader_CorruptedCache(t *testing.T) {
+	reader := &countingReader{val: cty.StringVal("bucket")}
+	options := ReadCacheOptions{Dir: tempCacheDir(t), TTL: time.Hour}
+	args := ReadResourceArgs{Ty: "aws_s3_bucket", ID: "bucket"}
+	cached := NewCachedResourceReader(reader, options, ReadCacheScope{})
+
+	if err := ioutil.WriteFile(path.Join(options.Dir, cached.key(args)+".json"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := cached.ReadResource(args)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "bucket", got.AsString())
+	assert.Equal(t, 1, reader.reads)
+}
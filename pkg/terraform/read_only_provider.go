@@ -0,0 +1,35 @@
+package terraform
+
+import (
+	"fmt"
+
+	tfproviders "github.com/hashicorp/terraform/providers"
+	"github.com/hashicorp/terraform/tfdiags"
+)
+
+// ReadOnlyProvider guards a terraform provider, changes of resources can't be planned nor applied through it.
+// Every call made by driftctl to a provider goes through it, so resources are only ever read
+type ReadOnlyProvider struct {
+	tfproviders.Interface
+}
+
+func NewReadOnlyProvider(provider tfproviders.Interface) *ReadOnlyProvider {
+	return &ReadOnlyProvider{provider}
+}
+
+func (p *ReadOnlyProvider) PlanResourceChange(req tfproviders.PlanResourceChangeRequest) tfproviders.PlanResourceChangeResponse {
+	return tfproviders.PlanResourceChangeResponse{
+		Diagnostics: blocked("PlanResourceChange", req.TypeName),
+	}
+}
+
+func (p *ReadOnlyProvider) ApplyResourceChange(req tfproviders.ApplyResourceChangeRequest) tfproviders.ApplyResourceChangeResponse {
+	return tfproviders.ApplyResourceChangeResponse{
+		Diagnostics: blocked("ApplyResourceChange", req.TypeName),
+	}
+}
+
+func blocked(call, typeName string) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	return diags.Append(fmt.Errorf("%s of %s is blocked, driftctl only reads resources through terraform providers", call, typeName))
+}
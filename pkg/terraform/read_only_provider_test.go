@@ -0,0 +1,37 @@
+package terraform
+
+import (
+	"testing"
+
+	tfproviders "github.com/hashicorp/terraform/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProvider struct {
+	tfproviders.Interface
+	reads int
+}
+
+func (p *fakeProvider) ReadResource(tfproviders.ReadResourceRequest) tfproviders.ReadResourceResponse {
+	p.reads++
+	return tfproviders.ReadResourceResponse{}
+}
+
+func (p *fakeProvider) ApplyResourceChange(tfproviders.ApplyResourceChangeRequest) tfproviders.ApplyResourceChangeResponse {
+	panic("changes must not be applied")
+}
+
+func TestReadOnlyProvider(t *testing.T) {
+	fake := &fakeProvider{}
+	provider := NewReadOnlyProvider(fake)
+
+	resp := provider.ReadResource(tfproviders.ReadResourceRequest{TypeName: "aws_instance"})
+	assert.False(t, resp.Diagnostics.HasErrors())
+	assert.Equal(t, 1, fake.reads)
+
+	planResp := provider.PlanResourceChange(tfproviders.PlanResourceChangeRequest{TypeName: "aws_instance"})
+	assert.EqualError(t, planResp.Diagnostics.Err(), "PlanResourceChange of aws_instance is blocked, driftctl only reads resources through terraform providers")
+
+	applyResp := provider.ApplyResourceChange(tfproviders.ApplyResourceChangeRequest{TypeName: "aws_instance"})
+	assert.EqualError(t, applyResp.Diagnostics.Err(), "ApplyResourceChange of aws_instance is blocked, driftctl only reads resources through terraform providers")
+}
@@ -1,8 +1,14 @@
 package resource
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
 
 type FakeResource struct {
+	resource.Metadata `json:"-" diff:"-"`
+
 	Id        string
 	FooBar    string
 	BarFoo    string `computed:"true"`